@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"text/template"
+)
+
+const (
+	formatJSON     = "json"
+	formatMarkdown = "markdown"
+)
+
+// generatorProgram calls the validator function and writes its documentation to standard output.
+// Functions of other packages cannot be called dynamically, hence the program is compiled with the package.
+var generatorProgram = template.Must(template.New("main").Parse(`package main
+
+import (
+{{- if not .Markdown }}
+	"encoding/json"
+{{- end }}
+	"fmt"
+	"os"
+
+	"github.com/nieomylnieja/govydoc/pkg/govydoc"
+
+	target "{{ .PkgPath }}"
+)
+
+func main() {
+	doc, err := govydoc.Generate(target.{{ .Name }}())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+{{- if .Markdown }}
+	markdown, err := govydoc.RenderMarkdown(doc)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Print(markdown)
+{{- else }}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(doc); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+{{- end }}
+}
+`))
+
+// generate runs a program generating the documentation of the validator in the given format.
+// The program is placed in a temporary directory of the validator's module, so that it uses the module's
+// dependencies and finds the module's root, which the Go documentation is loaded from.
+// The directory is removed once the program exits, including when ctx is canceled, which kills the program.
+func generate(ctx context.Context, validator validatorFunc, format string, stderr io.Writer) ([]byte, error) {
+	var program bytes.Buffer
+	err := generatorProgram.Execute(&program, struct {
+		PkgPath  string
+		Name     string
+		Markdown bool
+	}{
+		PkgPath:  validator.pkgPath,
+		Name:     validator.name,
+		Markdown: format == formatMarkdown,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render generator program: %w", err)
+	}
+
+	// Directories starting with "." are ignored by the "./..." pattern.
+	dir, err := os.MkdirTemp(validator.moduleDir, ".govydoc-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create generator program directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	if err = os.WriteFile(filepath.Join(dir, "main.go"), program.Bytes(), 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write generator program: %w", err)
+	}
+
+	var stdout bytes.Buffer
+	cmd := exec.CommandContext(ctx, "go", "run", ".")
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = stderr
+	if err = cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to generate documentation for %s.%s: %w", validator.pkgPath, validator.name, err)
+	}
+	return stdout.Bytes(), nil
+}
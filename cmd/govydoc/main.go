@@ -0,0 +1,114 @@
+// Command govydoc generates documentation for govy validators declared in Go packages.
+//
+// Usage:
+//
+//	govydoc generate <package> --validator <function> [--type <type>] [--format json|markdown] [--output <file>]
+//
+// The validator is returned by an exported function of the package,
+// which takes no arguments and returns [govy.Validator], e.g.:
+//
+//	func TeacherValidator() govy.Validator[Teacher]
+//
+// The command must be run from within the Go module containing the package,
+// which must depend on the govydoc module.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+const usage = `Usage:
+  govydoc generate <package> --validator <function> [--type <type>] [--format json|markdown] [--output <file>]
+`
+
+func main() {
+	// Interrupting the command cancels the context instead of exiting right away,
+	// so that the generator program is stopped and its directory is removed from the module.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "govydoc: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		_, _ = io.WriteString(stderr, usage)
+		return errors.New("missing command")
+	}
+	switch args[0] {
+	case "generate":
+		return runGenerate(ctx, args[1:], stdout, stderr)
+	case "help", "-h", "--help":
+		_, _ = io.WriteString(stdout, usage)
+		return nil
+	default:
+		_, _ = io.WriteString(stderr, usage)
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+func runGenerate(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	validatorName := flags.String("validator", "", "name of the exported function returning the validator")
+	typeName := flags.String("type", "", "name of the documented type, checked against the validator's type")
+	format := flags.String("format", formatJSON, "output format, either json or markdown")
+	output := flags.String("output", "", "file to write the documentation to, defaults to standard output")
+	positional, err := parseInterspersed(flags, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) != 1 {
+		return errors.New("exactly one package must be provided")
+	}
+	if *validatorName == "" {
+		return errors.New("--validator flag is required")
+	}
+	if *format != formatJSON && *format != formatMarkdown {
+		return fmt.Errorf("unsupported format %q, must be either %s or %s", *format, formatJSON, formatMarkdown)
+	}
+
+	validator, err := findValidator(positional[0], *validatorName, *typeName)
+	if err != nil {
+		return err
+	}
+	doc, err := generate(ctx, validator, *format, stderr)
+	if err != nil {
+		return err
+	}
+	if *output == "" {
+		_, err = stdout.Write(doc)
+		return err
+	}
+	if err = os.WriteFile(*output, doc, 0o600); err != nil {
+		return fmt.Errorf("failed to write documentation: %w", err)
+	}
+	return nil
+}
+
+// parseInterspersed parses flags placed both before and after positional arguments,
+// e.g. "./model --validator TeacherValidator", and returns the positional arguments.
+func parseInterspersed(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		if flags.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, flags.Arg(0))
+		args = flags.Args()[1:]
+	}
+}
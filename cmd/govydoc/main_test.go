@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nieomylnieja/govydoc/pkg/govydoc"
+)
+
+const (
+	validatorsPackage     = "./testdata/validators"
+	validatorsPackagePath = "github.com/nieomylnieja/govydoc/cmd/govydoc/testdata/validators"
+)
+
+func TestRun_Generate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("compiles and runs a generator program")
+	}
+
+	t.Run("json", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		err := run(
+			t.Context(),
+			[]string{"generate", validatorsPackage, "--validator", "TeacherValidator", "--type", "testmodels.Teacher"},
+			&stdout,
+			&stderr,
+		)
+		require.NoError(t, err, stderr.String())
+
+		var doc govydoc.ObjectDoc
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &doc))
+		assert.Equal(t, "Teacher", doc.Name)
+		require.NotEmpty(t, doc.Properties)
+		assert.Equal(t, "$", doc.Properties[0].Path.String())
+	})
+
+	t.Run("markdown to file", func(t *testing.T) {
+		output := filepath.Join(t.TempDir(), "teacher.md")
+		var stdout, stderr bytes.Buffer
+		err := run(
+			t.Context(),
+			[]string{
+				"generate", "--format", "markdown", "--output", output,
+				validatorsPackage, "--validator", "TeacherValidator",
+			},
+			&stdout,
+			&stderr,
+		)
+		require.NoError(t, err, stderr.String())
+
+		assert.Empty(t, stdout.String())
+		markdown, err := os.ReadFile(output)
+		require.NoError(t, err)
+		assert.Contains(t, string(markdown), "# Teacher\n")
+		assert.Contains(t, string(markdown), "## `$.name`\n")
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		var stdout, stderr bytes.Buffer
+		err := run(ctx, []string{"generate", validatorsPackage, "--validator", "TeacherValidator"}, &stdout, &stderr)
+		require.ErrorIs(t, err, context.Canceled)
+
+		validator, err := findValidator(validatorsPackage, "TeacherValidator", "")
+		require.NoError(t, err)
+		programDirs, err := filepath.Glob(filepath.Join(validator.moduleDir, ".govydoc-*"))
+		require.NoError(t, err)
+		assert.Empty(t, programDirs)
+	})
+}
+
+func TestRun_InvalidArguments(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		args     []string
+		expected string
+	}{
+		"no command": {
+			expected: "missing command",
+		},
+		"unknown command": {
+			args:     []string{"render"},
+			expected: `unknown command "render"`,
+		},
+		"no package": {
+			args:     []string{"generate", "--validator", "TeacherValidator"},
+			expected: "exactly one package must be provided",
+		},
+		"many packages": {
+			args:     []string{"generate", validatorsPackage, "./model", "--validator", "TeacherValidator"},
+			expected: "exactly one package must be provided",
+		},
+		"no validator": {
+			args:     []string{"generate", validatorsPackage},
+			expected: "--validator flag is required",
+		},
+		"unsupported format": {
+			args:     []string{"generate", validatorsPackage, "--validator", "TeacherValidator", "--format", "html"},
+			expected: `unsupported format "html", must be either json or markdown`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr bytes.Buffer
+			err := run(t.Context(), test.args, &stdout, &stderr)
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestFindValidator(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		name          string
+		typeName      string
+		expectedError string
+	}{
+		"valid": {
+			name: "TeacherValidator",
+		},
+		"qualified type name": {
+			name:     "TeacherValidator",
+			typeName: "testmodels.Teacher",
+		},
+		"unqualified type name": {
+			name:     "TeacherValidator",
+			typeName: "Teacher",
+		},
+		"different type": {
+			name:          "TeacherValidator",
+			typeName:      "Student",
+			expectedError: "function TeacherValidator returns a validator of testmodels.Teacher, not Student",
+		},
+		"not found": {
+			name:          "StudentValidator",
+			expectedError: "function StudentValidator not found in package " + validatorsPackagePath,
+		},
+		"not a function": {
+			name:          "Teacher",
+			expectedError: "Teacher is not a function",
+		},
+		"unexported": {
+			name:          "unexportedValidator",
+			expectedError: "function unexportedValidator must be exported",
+		},
+		"with arguments": {
+			name:          "WithArguments",
+			expectedError: "function WithArguments cannot be used: it must not take any arguments",
+		},
+		"not a validator": {
+			name:          "NotValidator",
+			expectedError: "function NotValidator cannot be used: it returns string instead of govy.Validator",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			validator, err := findValidator(validatorsPackage, test.name, test.typeName)
+			if test.expectedError != "" {
+				require.EqualError(t, err, test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, validatorsPackagePath, validator.pkgPath)
+			assert.Equal(t, test.name, validator.name)
+			assert.FileExists(t, filepath.Join(validator.moduleDir, "go.mod"))
+		})
+	}
+
+	t.Run("invalid package", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := findValidator("./testdata/missing", "TeacherValidator", "")
+		require.ErrorContains(t, err, "failed to load package ./testdata/missing")
+	})
+}
@@ -0,0 +1,37 @@
+// Package validators declares validators used to test the govydoc command.
+package validators
+
+import (
+	"github.com/nobl9/govy/pkg/govy"
+	"github.com/nobl9/govy/pkg/rules"
+
+	"github.com/nieomylnieja/govydoc/internal/testmodels"
+)
+
+// TeacherValidator returns a valid validator function.
+func TeacherValidator() govy.Validator[testmodels.Teacher] {
+	return govy.New(
+		govy.For(func(t testmodels.Teacher) string { return t.Name }).
+			WithName("name").
+			Rules(rules.EQ("John")),
+	).WithName("Teacher")
+}
+
+// WithArguments cannot be called without arguments.
+func WithArguments(name string) govy.Validator[testmodels.Teacher] {
+	return govy.New[testmodels.Teacher]().WithName(name)
+}
+
+// NotValidator does not return a validator.
+func NotValidator() string {
+	return "teacher"
+}
+
+// Teacher is not a function.
+var Teacher = TeacherValidator()
+
+func unexportedValidator() govy.Validator[testmodels.Teacher] {
+	return govy.New[testmodels.Teacher]()
+}
+
+var _ = unexportedValidator
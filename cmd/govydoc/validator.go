@@ -0,0 +1,100 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+)
+
+const govyPackage = "github.com/nobl9/govy/pkg/govy"
+
+// validatorFunc is an exported function returning a validator, found by [findValidator].
+type validatorFunc struct {
+	pkgPath string
+	name    string
+	// moduleDir is the root directory of the module declaring the function.
+	moduleDir string
+}
+
+// findValidator loads the package matching pattern and returns its exported function named name,
+// which must take no arguments and return a [govy.Validator].
+// If typeName is not empty, the validator's type must be named typeName.
+func findValidator(pattern, name, typeName string) (validatorFunc, error) {
+	config := &packages.Config{Mode: packages.NeedName | packages.NeedTypes | packages.NeedModule}
+	pkgs, err := packages.Load(config, pattern)
+	if err != nil {
+		return validatorFunc{}, fmt.Errorf("failed to load package %s: %w", pattern, err)
+	}
+	if len(pkgs) != 1 {
+		return validatorFunc{}, fmt.Errorf("pattern %s must match exactly one package, matched %d", pattern, len(pkgs))
+	}
+	pkg := pkgs[0]
+	if len(pkg.Errors) > 0 {
+		return validatorFunc{}, fmt.Errorf("failed to load package %s: %w", pattern, pkg.Errors[0])
+	}
+	if pkg.Name == "main" {
+		return validatorFunc{}, fmt.Errorf("package %s is a main package, which cannot be imported", pkg.PkgPath)
+	}
+	if pkg.Module == nil {
+		return validatorFunc{}, fmt.Errorf("package %s does not belong to a module", pkg.PkgPath)
+	}
+
+	// The function is called from another package. Unexported functions cannot be looked up anyway,
+	// as the package's types are loaded from its export data.
+	if !token.IsExported(name) {
+		return validatorFunc{}, fmt.Errorf("function %s must be exported", name)
+	}
+	obj := pkg.Types.Scope().Lookup(name)
+	if obj == nil {
+		return validatorFunc{}, fmt.Errorf("function %s not found in package %s", name, pkg.PkgPath)
+	}
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return validatorFunc{}, fmt.Errorf("%s is not a function", name)
+	}
+	validatedType, err := validatedType(fn.Signature())
+	if err != nil {
+		return validatorFunc{}, fmt.Errorf("function %s cannot be used: %w", name, err)
+	}
+	if typeName != "" && !isTypeNamed(validatedType, pkg.Types, typeName) {
+		return validatorFunc{}, fmt.Errorf("function %s returns a validator of %s, not %s",
+			name, types.TypeString(validatedType, packageNameQualifier(pkg.Types)), typeName)
+	}
+	return validatorFunc{pkgPath: pkg.PkgPath, name: name, moduleDir: pkg.Module.Dir}, nil
+}
+
+// validatedType returns T of the function's govy.Validator[T] result.
+func validatedType(signature *types.Signature) (types.Type, error) {
+	if signature.Params().Len() != 0 {
+		return nil, errors.New("it must not take any arguments")
+	}
+	if signature.Results().Len() != 1 {
+		return nil, errors.New("it must return a single govy.Validator")
+	}
+	named, ok := signature.Results().At(0).Type().(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != govyPackage || named.Obj().Name() != "Validator" {
+		return nil, fmt.Errorf("it returns %s instead of govy.Validator", signature.Results().At(0).Type())
+	}
+	return named.TypeArgs().At(0), nil
+}
+
+// isTypeNamed reports whether typ is named typeName, either qualified with its package name, e.g. "model.Teacher",
+// or not, e.g. "Teacher". Types of pkg are not qualified.
+func isTypeNamed(typ types.Type, pkg *types.Package, typeName string) bool {
+	unqualified := func(*types.Package) string { return "" }
+	return typeName == types.TypeString(typ, packageNameQualifier(pkg)) ||
+		typeName == types.TypeString(typ, unqualified)
+}
+
+// packageNameQualifier qualifies types with their package names, except for the types of pkg.
+func packageNameQualifier(pkg *types.Package) types.Qualifier {
+	return func(other *types.Package) string {
+		if other == pkg {
+			return ""
+		}
+		return other.Name()
+	}
+}
@@ -0,0 +1,228 @@
+package godoc
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go/doc/comment"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/nieomylnieja/govydoc/internal/modroot"
+)
+
+// cacheVersion is a part of every cache key and must be changed whenever [Doc] or [cacheEntry] change.
+const cacheVersion = "8"
+
+// Cache stores the documentation returned by [Parser.ParseWithOptions] on disk, so that it can be read
+// without loading the module's packages.
+// Entries are keyed by the documented type, the [ParseOptions], and the digest of the modules' state,
+// which covers go.mod, go.sum, the Go version, and the modules' non-test Go source files.
+// Test files are covered as well if [ParseOptions.Examples] is set.
+type Cache struct {
+	dir          string
+	moduleDigest string
+	// testsDigest computes the module's digest covering test files on first use.
+	testsDigest func() (string, error)
+}
+
+type cacheEntry struct {
+	Docs     Docs
+	Warnings []string
+}
+
+func init() {
+	// Register the implementations of comment.Block and comment.Text, which gob encodes as interfaces.
+	gob.Register(&comment.Heading{})
+	gob.Register(&comment.List{})
+	gob.Register(&comment.Paragraph{})
+	gob.Register(&comment.Code{})
+	gob.Register(comment.Plain(""))
+	gob.Register(comment.Italic(""))
+	gob.Register(&comment.Link{})
+	gob.Register(&comment.DocLink{})
+}
+
+// NewCache returns a [Cache] storing its entries in dir for the Go modules with the roots.
+// The roots are discovered like by [NewParser] if none are given.
+func NewCache(dir string, roots ...string) (*Cache, error) {
+	roots, err := modroot.Resolve(roots)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find module root: %w", err)
+	}
+	digest, err := modulesDigest(roots, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute module digest: %w", err)
+	}
+	return &Cache{
+		dir:          dir,
+		moduleDigest: digest,
+		testsDigest:  sync.OnceValues(func() (string, error) { return modulesDigest(roots, true) }),
+	}, nil
+}
+
+// Load returns the cached documentation of goType parsed with options.
+// It returns false if the documentation is not cached, or if the cache entry cannot be read.
+func (c *Cache) Load(goType reflect.Type, options ParseOptions) (Docs, []string, bool) {
+	path, err := c.entryPath(goType, options)
+	if err != nil {
+		return nil, nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, false
+	}
+	var entry cacheEntry
+	if err = gob.NewDecoder(bytes.NewReader(data)).Decode(&entry); err != nil {
+		return nil, nil, false
+	}
+	return entry.Docs, entry.Warnings, true
+}
+
+// Store writes the documentation of goType parsed with options to the cache, replacing the existing entry.
+func (c *Cache) Store(goType reflect.Type, options ParseOptions, docs Docs, warnings []string) error {
+	path, err := c.entryPath(goType, options)
+	if err != nil {
+		return fmt.Errorf("failed to compute module digest: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(cacheEntry{Docs: docs, Warnings: warnings}); err != nil {
+		return fmt.Errorf("failed to encode documentation of %s: %w", goType, err)
+	}
+	if err := os.MkdirAll(c.dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	// Write to a temporary file first, so that concurrent readers never see a partially written entry.
+	file, err := os.CreateTemp(c.dir, "entry-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create cache entry: %w", err)
+	}
+	_, err = file.Write(buf.Bytes())
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	return nil
+}
+
+func (c *Cache) entryPath(goType reflect.Type, options ParseOptions) (string, error) {
+	digest := c.moduleDigest
+	if options.Examples {
+		var err error
+		if digest, err = c.testsDigest(); err != nil {
+			return "", err
+		}
+	}
+	key := sha256.Sum256(fmt.Appendf(nil, "%s\x00%s\x00%s\x00%s\x00%t\x00%t\x00%t\x00%t\x00%s",
+		cacheVersion, digest, typeIdentity(goType),
+		options.TagName, options.NestEmbedded, options.IncludeHidden, options.TrailingComments, options.Examples,
+		options.DocLinkBaseURL))
+	return filepath.Join(c.dir, hex.EncodeToString(key[:])+".gob"), nil
+}
+
+// typeIdentity returns a string identifying goType across packages,
+// unlike [reflect.Type.String], which qualifies named types with package names instead of paths.
+func typeIdentity(goType reflect.Type) string {
+	switch goType.Kind() {
+	case reflect.Pointer:
+		return "*" + typeIdentity(goType.Elem())
+	case reflect.Slice:
+		return "[]" + typeIdentity(goType.Elem())
+	case reflect.Array:
+		return fmt.Sprintf("[%d]%s", goType.Len(), typeIdentity(goType.Elem()))
+	case reflect.Map:
+		return "map[" + typeIdentity(goType.Key()) + "]" + typeIdentity(goType.Elem())
+	default:
+		if goType.PkgPath() == "" {
+			return goType.String()
+		}
+		return goType.PkgPath() + "." + goType.Name()
+	}
+}
+
+// modulesDigest combines the [moduleDigest] of every module root.
+func modulesDigest(roots []string, includeTests bool) (string, error) {
+	if len(roots) == 1 {
+		return moduleDigest(roots[0], includeTests)
+	}
+	hash := sha256.New()
+	for _, root := range roots {
+		digest, err := moduleDigest(root, includeTests)
+		if err != nil {
+			return "", err
+		}
+		_, _ = fmt.Fprintf(hash, "%s\x00%s\x00", filepath.ToSlash(root), digest)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// moduleDigest hashes the Go version and the module's files which affect the parsed documentation,
+// that is, go.mod, go.sum, and the Go source files matched by the "./..." pattern, excluding tests
+// unless includeTests is set.
+// Changes to the source of dependencies are covered by go.sum,
+// except for dependencies replaced with local directories.
+func moduleDigest(root string, includeTests bool) (string, error) {
+	hash := sha256.New()
+	_, _ = io.WriteString(hash, runtime.Version()+"\x00")
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != root && isIgnoredPackageDir(path, entry.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !isDigestedFile(path, root, entry.Name(), includeTests) {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		relativePath, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(hash, "%s\x00%d\x00", filepath.ToSlash(relativePath), len(data))
+		_, _ = hash.Write(data)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// isIgnoredPackageDir reports whether the directory is ignored by the "./..." pattern,
+// which skips testdata, directories starting with "." or "_", and nested modules.
+func isIgnoredPackageDir(path, name string) bool {
+	if name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+		return true
+	}
+	_, err := os.Stat(filepath.Join(path, "go.mod"))
+	return !errors.Is(err, fs.ErrNotExist)
+}
+
+func isDigestedFile(path, root, name string, includeTests bool) bool {
+	if filepath.Dir(path) == root && (name == "go.mod" || name == "go.sum") {
+		return true
+	}
+	return strings.HasSuffix(name, ".go") && (includeTests || !strings.HasSuffix(name, "_test.go"))
+}
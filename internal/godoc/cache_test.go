@@ -0,0 +1,139 @@
+package godoc
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nieomylnieja/govydoc/internal/testmodels"
+)
+
+func TestCache(t *testing.T) {
+	parser := newTestParser(t)
+	typ := reflect.TypeFor[testmodels.Teacher]()
+	docs, warnings, err := parser.Parse(typ)
+	require.NoError(t, err)
+
+	cache, err := NewCache(filepath.Join(t.TempDir(), "cache"))
+	require.NoError(t, err)
+
+	_, _, found := cache.Load(typ, ParseOptions{})
+	assert.False(t, found)
+
+	require.NoError(t, cache.Store(typ, ParseOptions{}, docs, warnings))
+	cachedDocs, cachedWarnings, found := cache.Load(typ, ParseOptions{})
+	require.True(t, found)
+	assert.Equal(t, docs, cachedDocs)
+	assert.Equal(t, warnings, cachedWarnings)
+
+	_, _, found = cache.Load(reflect.TypeFor[testmodels.Student](), ParseOptions{})
+	assert.False(t, found)
+	_, _, found = cache.Load(typ, ParseOptions{TagName: "yaml"})
+	assert.False(t, found)
+	_, _, found = cache.Load(typ, ParseOptions{TrailingComments: true})
+	assert.False(t, found)
+	_, _, found = cache.Load(typ, ParseOptions{Examples: true})
+	assert.False(t, found)
+
+	t.Run("corrupted entry", func(t *testing.T) {
+		path, err := cache.entryPath(typ, ParseOptions{})
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, []byte("corrupted"), 0o600))
+		_, _, found := cache.Load(typ, ParseOptions{})
+		assert.False(t, found)
+	})
+}
+
+func Test_moduleDigest(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile := func(path, content string) {
+		t.Helper()
+		path = filepath.Join(root, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	digest := func() string {
+		t.Helper()
+		digest, err := moduleDigest(root, false)
+		require.NoError(t, err)
+		return digest
+	}
+	testsDigest := func() string {
+		t.Helper()
+		digest, err := moduleDigest(root, true)
+		require.NoError(t, err)
+		return digest
+	}
+	writeFile("go.mod", "module example.com/cache\n")
+	writeFile("pkg/model.go", "package pkg\n")
+	initial := digest()
+	assert.Equal(t, initial, testsDigest())
+
+	t.Run("ignored files", func(t *testing.T) {
+		writeFile("pkg/model_test.go", "package pkg\n")
+		writeFile("pkg/testdata/data.go", "package data\n")
+		writeFile(".git/hooks.go", "package hooks\n")
+		writeFile("_tools/tools.go", "package tools\n")
+		writeFile("nested/go.mod", "module example.com/nested\n")
+		writeFile("nested/nested.go", "package nested\n")
+		writeFile("README.md", "# cache\n")
+		assert.Equal(t, initial, digest())
+	})
+
+	t.Run("test files", func(t *testing.T) {
+		withTests := testsDigest()
+		assert.NotEqual(t, initial, withTests)
+
+		writeFile("pkg/model_test.go", "package pkg\n\nfunc ExampleModel() {}\n")
+		assert.NotEqual(t, withTests, testsDigest())
+		assert.Equal(t, initial, digest())
+	})
+
+	t.Run("changed files", func(t *testing.T) {
+		writeFile("pkg/model.go", "package pkg\n\n// Model is documented.\ntype Model struct{}\n")
+		changedSource := digest()
+		assert.NotEqual(t, initial, changedSource)
+
+		writeFile("go.sum", "example.com/dep v1.0.0 h1:hash=\n")
+		assert.NotEqual(t, changedSource, digest())
+	})
+}
+
+func Test_typeIdentity(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		typ      reflect.Type
+		expected string
+	}{
+		"named type": {
+			typ:      reflect.TypeFor[testmodels.Teacher](),
+			expected: testModelsPackage + ".Teacher",
+		},
+		"built-in type": {
+			typ:      reflect.TypeFor[string](),
+			expected: "string",
+		},
+		"composite type": {
+			typ:      reflect.TypeFor[map[string][]*testmodels.Teacher](),
+			expected: "map[string][]*" + testModelsPackage + ".Teacher",
+		},
+		"array type": {
+			typ:      reflect.TypeFor[[2]testmodels.Student](),
+			expected: "[2]" + testModelsPackage + ".Student",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, typeIdentity(test.typ))
+		})
+	}
+}
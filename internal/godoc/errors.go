@@ -0,0 +1,51 @@
+package godoc
+
+import (
+	"errors"
+	"fmt"
+)
+
+// PackageLoadError is an error reported by the Go toolchain for a package, e.g. a syntax or type error.
+type PackageLoadError struct {
+	// Path is the import path of the package.
+	Path string
+	Err  error
+}
+
+func (e *PackageLoadError) Error() string {
+	return fmt.Sprintf("package %s has reported an error: %v", e.Path, e.Err)
+}
+
+func (e *PackageLoadError) Unwrap() error {
+	return e.Err
+}
+
+// ModuleLoadError is an error reported by the Go toolchain for a module, e.g. a missing go.sum entry.
+type ModuleLoadError struct {
+	// Path is the module path.
+	Path string
+	Err  error
+}
+
+func (e *ModuleLoadError) Error() string {
+	return fmt.Sprintf("module %s has error: %v", e.Path, e.Err)
+}
+
+func (e *ModuleLoadError) Unwrap() error {
+	return e.Err
+}
+
+// LoadErrors aggregates the [PackageLoadError] and [ModuleLoadError] errors encountered while loading packages.
+// Use [errors.As] to find a specific error.
+type LoadErrors []error
+
+func (e LoadErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	return fmt.Sprintf("encountered %d errors while loading packages: %v", len(e), errors.Join(e...))
+}
+
+func (e LoadErrors) Unwrap() []error {
+	return e
+}
@@ -0,0 +1,128 @@
+package godoc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/ast"
+	"go/doc"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Example is a testable example function of a type, e.g. ExampleTeacher or ExampleTeacher_json,
+// see [ParseOptions.Examples].
+type Example struct {
+	// Name is the name of the example function.
+	Name string
+	// Code is the source of the function's body, including its output comment.
+	Code string
+}
+
+// testExamples holds the examples declared in a package's test files.
+type testExamples struct {
+	fset     *token.FileSet
+	examples []*doc.Example
+}
+
+// parseTestExamples parses the examples declared in the test files of the package's directory.
+func parseTestExamples(pkg *goPackage) (testExamples, error) {
+	fset := token.NewFileSet()
+	if len(pkg.pkg.GoFiles) == 0 {
+		return testExamples{fset: fset}, nil
+	}
+	paths, err := filepath.Glob(filepath.Join(filepath.Dir(pkg.pkg.GoFiles[0]), "*_test.go"))
+	if err != nil {
+		return testExamples{}, err
+	}
+	files := make([]*ast.File, 0, len(paths))
+	for _, path := range paths {
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return testExamples{}, fmt.Errorf("failed to parse test file: %w", err)
+		}
+		files = append(files, file)
+	}
+	return testExamples{fset: fset, examples: doc.Examples(files...)}, nil
+}
+
+// addExamples sets [Doc.Examples] of the documented goType, for [ParseOptions.Examples].
+// Examples which cannot be parsed are reported as warnings.
+func (p *Parser) addExamples(goType reflect.Type, state *parseState) {
+	for slices.Contains([]reflect.Kind{reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map}, goType.Kind()) {
+		goType = goType.Elem()
+	}
+	key := Doc{Name: goType.Name(), Package: goType.PkgPath()}.Key()
+	typeDoc, ok := state.docs[key]
+	if !ok {
+		return
+	}
+	examples, err := p.parseExamples(state.ctx, goType)
+	if err != nil {
+		state.warnings = append(state.warnings, fmt.Sprintf("examples of type %s are not documented: %v", goType, err))
+		return
+	}
+	typeDoc.Examples = examples
+	state.docs.add(typeDoc)
+}
+
+// parseExamples returns the examples of the named type, that is, the functions named Example<TypeName>
+// or Example<TypeName>_<suffix>, declared in the test files of the type's package.
+func (p *Parser) parseExamples(ctx context.Context, goType reflect.Type) ([]Example, error) {
+	pkg, err := p.getPackage(ctx, goType.PkgPath())
+	if err != nil || pkg == nil {
+		return nil, err
+	}
+	parsed, err := pkg.testExamples()
+	if err != nil {
+		return nil, err
+	}
+	declName, _, _ := strings.Cut(goType.Name(), "[")
+	var examples []Example
+	for _, example := range parsed.examples {
+		if exampleTypeName(example.Name) != declName {
+			continue
+		}
+		code, err := exampleCode(parsed.fset, example)
+		if err != nil {
+			return nil, err
+		}
+		examples = append(examples, Example{Name: "Example" + example.Name, Code: code})
+	}
+	return examples, nil
+}
+
+// exampleTypeName returns the name of the example function without the "Example" prefix and its suffix,
+// which starts with a lower-case letter, e.g. Teacher for ExampleTeacher_json.
+func exampleTypeName(name string) string {
+	i := strings.LastIndex(name, "_")
+	if i < 0 || i == len(name)-1 {
+		return name
+	}
+	if r, _ := utf8.DecodeRuneInString(name[i+1:]); unicode.IsUpper(r) {
+		return name
+	}
+	return name[:i]
+}
+
+// exampleCode formats the body of the example function, without its braces and indentation.
+func exampleCode(fset *token.FileSet, example *doc.Example) (string, error) {
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, &printer.CommentedNode{Node: example.Code, Comments: example.Comments}); err != nil {
+		return "", fmt.Errorf("failed to format example %s: %w", example.Name, err)
+	}
+	code := strings.TrimSuffix(strings.TrimPrefix(buf.String(), "{"), "}")
+	lines := strings.Split(strings.Trim(code, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimPrefix(line, "\t")
+	}
+	return strings.Join(lines, "\n"), nil
+}
@@ -2,15 +2,20 @@
 package godoc
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"go/ast"
+	"go/constant"
 	"go/doc/comment"
+	"go/token"
 	"go/types"
 	"maps"
+	"path/filepath"
 	"reflect"
 	"slices"
 	"strings"
+	"sync"
 
 	"golang.org/x/tools/go/ast/astutil"
 	"golang.org/x/tools/go/packages"
@@ -18,58 +23,257 @@ import (
 	"github.com/nieomylnieja/govydoc/internal/modroot"
 )
 
-const docLinkBaseURL = "https://pkg.go.dev"
+// DefaultDocLinkBaseURL is the base URL of the documentation doc links, e.g. [fmt.Stringer], point to.
+const DefaultDocLinkBaseURL = "https://pkg.go.dev"
+
+// DefaultTagName is the struct tag [Parser.Parse] reads the names of struct fields from.
+const DefaultTagName = "json"
 
 // Docs maps fully qualified Go type names to their documentation.
 type Docs map[string]Doc
 
 // Doc describes a Go type and, for structs, its fields.
 type Doc struct {
-	Name         string
-	Package      string
-	Doc          string
+	Name    string
+	Package string
+	Doc     string
+	// RawDoc is the comment text before it was converted to Markdown.
+	RawDoc string
+	// HTMLDoc is the comment rendered as HTML.
+	HTMLDoc string
+	// TextDoc is the comment rendered as plain text.
+	TextDoc string
+	// Comment is the parsed RawDoc, it is nil if there is no documentation.
+	Comment *comment.Doc
+	// StructFields documents the fields of a struct type, keyed by their names.
+	// For struct fields declared with an anonymous struct type, e.g. Config struct { Host string },
+	// or a pointer, slice, array, or map of such type, it documents the fields of the anonymous struct.
 	StructFields Docs
+	// FieldOrder lists the keys of StructFields in the order of their declaration.
+	// Fields promoted from embedded structs are placed where the embedded struct is declared.
+	FieldOrder []string
+	// Methods lists the methods of an interface type, including the ones of embedded interfaces,
+	// sorted by name.
+	Methods []Method
+	// DeclaredMethods lists the exported methods declared with a non-interface type as their receiver,
+	// either by value or by pointer, sorted by name.
+	DeclaredMethods []Method
+	// EnumConstants lists the exported constants declared with a non-struct, non-interface type
+	// in the type's package, in the order of their declaration, e.g. Red and Green for type Color string.
+	EnumConstants []EnumConstant
+	// SourcePos is the position of the struct field's declaration, it is only set for struct fields.
+	SourcePos SourcePos
+	// Examples lists the testable examples of the documented type, see [ParseOptions.Examples].
+	Examples []Example
+	// PackageDoc is the documentation of the documented type's package, that is, the package comment.
+	// It is only set for the type passed to [Parser.Parse], and nil if the package has no comment.
+	PackageDoc *Doc
+}
+
+// SourcePos is a position in a Go source file.
+type SourcePos struct {
+	// File is the path of the file, relative to the module root if the file belongs to the module.
+	// For multiple modules, e.g. of a workspace, it is relative to the deepest directory containing all of them.
+	File string
+	Line int
+}
+
+// Method describes a method of an interface type.
+type Method struct {
+	// Doc holds the method's name and documentation.
+	Doc
+	// Signature is the method's signature with package-local types unqualified, e.g. "String() string".
+	Signature string
+}
+
+// EnumConstant describes a constant declared with a named type, like a value of an enum.
+type EnumConstant struct {
+	// Doc holds the constant's name and documentation,
+	// which is its doc comment or, if it has none, its trailing line comment.
+	Doc
+	// Value is the constant's value, e.g. "red" for Red Color = "red" and "1" for the second iota constant.
+	Value string
 }
 
 // Parser extracts Go documentation from the packages in a module.
+// It is safe for concurrent use.
 type Parser struct {
+	// mu guards pkgs and loadErrs, which lazy parsers extend while parsing.
+	mu   sync.Mutex
 	pkgs map[string]*goPackage
+	// roots are the directories of the modules whose packages are documented.
+	roots []string
+	// dir is the directory source positions are relative to, the deepest one containing all the roots.
+	dir string
+	// lazy is set for parsers created with [NewLazyParser].
+	lazy bool
+	// loadErrs holds the errors of packages which failed to load lazily, so that loading them is not retried.
+	loadErrs map[string]error
 }
 
 type goPackage struct {
 	pkg           *packages.Package
 	commentParser *comment.Parser
+	// testExamples parses the examples of the package's test files on first use.
+	testExamples func() (testExamples, error)
 }
 
-// NewParser returns a parser initialized with every package reachable from the current Go module.
-func NewParser() (*Parser, error) {
-	root, err := modroot.Find()
+func (p *Parser) newGoPackage(pkg *packages.Package) *goPackage {
+	goPkg := &goPackage{pkg: pkg, commentParser: p.newCommentParserForPackage(pkg)}
+	goPkg.testExamples = sync.OnceValues(func() (testExamples, error) { return parseTestExamples(goPkg) })
+	return goPkg
+}
+
+// packageLoadMode loads the syntax and types of packages.
+const packageLoadMode = packages.NeedName |
+	packages.NeedFiles |
+	packages.NeedCompiledGoFiles |
+	packages.NeedImports |
+	packages.NeedTypes |
+	packages.NeedSyntax |
+	packages.NeedTypesInfo
+
+// NewParser returns a parser initialized with every package reachable from the Go modules with the roots.
+// If no roots are given, they are discovered with [modroot.FindAll], that is, they are the modules
+// of the current go.work workspace, or the current Go module if there is no workspace.
+// Loading the packages is stopped when ctx is done, in which case ctx's error is returned.
+func NewParser(ctx context.Context, roots ...string) (*Parser, error) {
+	roots, err := modroot.Resolve(roots)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find module root: %w", err)
 	}
 
-	config := &packages.Config{
-		Dir: root,
-		Mode: packages.NeedName |
-			packages.NeedFiles |
-			packages.NeedCompiledGoFiles |
-			packages.NeedImports |
-			packages.NeedDeps |
-			packages.NeedTypes |
-			packages.NeedSyntax |
-			packages.NeedTypesInfo,
+	parser := &Parser{pkgs: make(map[string]*goPackage), roots: roots, dir: commonDir(roots)}
+	for _, root := range roots {
+		config := &packages.Config{
+			Context: ctx,
+			Dir:     root,
+			Mode:    packageLoadMode | packages.NeedDeps,
+		}
+		pkgs, err := packages.Load(config, "./...")
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to load packages: %w", err)
+		}
+		if err = checkForPackageErrors(pkgs); err != nil {
+			return nil, err
+		}
+		parser.collectAllPackages(pkgs)
+	}
+	return parser, nil
+}
+
+// NewLazyParser returns a parser which loads packages on demand, when their types are parsed,
+// instead of loading every package reachable from the Go modules with the roots up front.
+// The roots are discovered like by [NewParser] if none are given.
+// Each package is loaded without its dependencies, whose types are read from export data.
+// Packages which fail to load are not documented, instead a warning is returned for their types.
+func NewLazyParser(roots ...string) (*Parser, error) {
+	roots, err := modroot.Resolve(roots)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find module root: %w", err)
+	}
+	return &Parser{
+		pkgs:     make(map[string]*goPackage),
+		roots:    roots,
+		dir:      commonDir(roots),
+		lazy:     true,
+		loadErrs: make(map[string]error),
+	}, nil
+}
+
+// commonDir returns the deepest directory containing all the roots.
+func commonDir(roots []string) string {
+	dir := roots[0]
+	for _, root := range roots[1:] {
+		for !isWithinDir(dir, root) && filepath.Dir(dir) != dir {
+			dir = filepath.Dir(dir)
+		}
+	}
+	return dir
+}
+
+// isWithinDir reports whether path is dir or is located in it.
+func isWithinDir(dir, path string) bool {
+	relativePath, err := filepath.Rel(dir, path)
+	return err == nil && filepath.IsLocal(relativePath)
+}
+
+// NumPackages returns the number of packages loaded by the parser.
+func (p *Parser) NumPackages() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.pkgs)
+}
+
+// getPackage returns the package with the import path, loading it first with ctx if the parser is lazy.
+// It returns nil if the package was not loaded by an eager parser.
+// Loading errors are cached, unless loading was stopped because ctx is done.
+func (p *Parser) getPackage(ctx context.Context, pkgPath string) (*goPackage, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if pkg, ok := p.pkgs[pkgPath]; ok || !p.lazy {
+		return pkg, nil
+	}
+	if err, ok := p.loadErrs[pkgPath]; ok {
+		return nil, err
+	}
+	pkg, err := p.loadPackage(ctx, pkgPath)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
 	}
-	pkgs, err := packages.Load(config, "./...")
 	if err != nil {
-		return nil, fmt.Errorf("failed to load packages: %w", err)
+		p.loadErrs[pkgPath] = err
+		return nil, err
+	}
+	p.pkgs[pkgPath] = pkg
+	return pkg, nil
+}
+
+// loadPackage loads the package with the import path, without its dependencies.
+// The package is loaded from the first module root it can be loaded from.
+func (p *Parser) loadPackage(ctx context.Context, pkgPath string) (*goPackage, error) {
+	var firstErr error
+	for _, root := range p.roots {
+		pkg, err := p.loadPackageFrom(ctx, root, pkgPath)
+		if err == nil {
+			return pkg, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return nil, firstErr
+}
+
+// loadPackageFrom loads the package with the import path from the module root, without its dependencies.
+func (p *Parser) loadPackageFrom(ctx context.Context, root, pkgPath string) (*goPackage, error) {
+	config := &packages.Config{Context: ctx, Dir: root, Mode: packageLoadMode}
+	pkgs, err := packages.Load(config, pkgPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("expected a single package, loaded %d", len(pkgs))
 	}
 	if err = checkForPackageErrors(pkgs); err != nil {
 		return nil, err
 	}
+	return p.newGoPackage(pkgs[0]), nil
+}
 
-	parser := &Parser{pkgs: make(map[string]*goPackage, len(pkgs))}
-	parser.collectAllPackages(pkgs)
-	return parser, nil
+// findPackageByName returns the import path of a loaded package with the name.
+func (p *Parser) findPackageByName(name string) (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, pkg := range p.pkgs {
+		if pkg.pkg.Name == name {
+			return pkg.pkg.PkgPath, true
+		}
+	}
+	return "", false
 }
 
 // Key returns the type's package-qualified name, or its name for built-in types.
@@ -81,30 +285,99 @@ func (d Doc) Key() string {
 }
 
 // Parse returns documentation for goType and the named types reachable through its fields.
-func (p *Parser) Parse(goType reflect.Type) (Docs, error) {
+// Types whose declarations cannot be found in the loaded packages, e.g. types declared in test files,
+// are not documented, instead a warning is returned for each of them.
+// Struct fields are keyed by their JSON names.
+func (p *Parser) Parse(goType reflect.Type) (Docs, []string, error) {
+	return p.ParseWithOptions(goType, ParseOptions{})
+}
+
+// ParseContext works like [Parser.ParseWithOptions], but stops loading packages when ctx is done,
+// in which case ctx's error is returned.
+// Only lazy parsers, see [NewLazyParser], load packages while parsing.
+func (p *Parser) ParseContext(ctx context.Context, goType reflect.Type, options ParseOptions) (Docs, []string, error) {
 	if goType == nil {
-		return nil, errors.New("type cannot be nil")
+		return nil, nil, errors.New("type cannot be nil")
+	}
+	if options.TagName == "" {
+		options.TagName = DefaultTagName
+	}
+	if options.DocLinkBaseURL == "" {
+		options.DocLinkBaseURL = DefaultDocLinkBaseURL
 	}
 
-	m := make(Docs)
-	if _, err := p.parse(goType, m); err != nil {
-		return nil, err
+	state := &parseState{ctx: ctx, docs: make(Docs), parsing: make(map[reflect.Type]Doc), options: options}
+	if _, err := p.parse(goType, state); err != nil {
+		return nil, nil, err
 	}
-	if len(m) == 0 {
-		return nil, fmt.Errorf("no documentation found for type %s", goType)
+	p.addPackageDoc(goType, state)
+	if options.Examples {
+		p.addExamples(goType, state)
 	}
-	return m, nil
+	// Packages which failed to load because ctx is done are skipped like the ones which failed to load.
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	if len(state.docs) == 0 && len(state.warnings) == 0 {
+		return nil, nil, fmt.Errorf("no documentation found for type %s", goType)
+	}
+	return state.docs, state.warnings, nil
+}
+
+// ParseOptions configures [Parser.ParseWithOptions].
+type ParseOptions struct {
+	// TagName is the struct tag the names of struct fields are read from, e.g. "yaml".
+	// Defaults to [DefaultTagName].
+	TagName string
+	// NestEmbedded documents exported embedded structs as fields named after their types,
+	// instead of promoting their fields to the parent struct.
+	NestEmbedded bool
+	// IncludeHidden documents unexported struct fields, and fields ignored with the "-" tag name,
+	// under their Go field names.
+	IncludeHidden bool
+	// TrailingComments documents struct fields without a doc comment with their trailing line comment,
+	// e.g. "Age of the teacher." for `Age int // Age of the teacher.`.
+	TrailingComments bool
+	// Examples sets [Doc.Examples] of the documented type to the testable examples
+	// declared in the test files of its package.
+	Examples bool
+	// DocLinkBaseURL is the base URL of the documentation doc links point to, e.g. "https://pkg.go.dev",
+	// which is followed by the import path of the linked package.
+	// Defaults to [DefaultDocLinkBaseURL].
+	DocLinkBaseURL string
+}
+
+// ParseWithOptions works like [Parser.Parse], but allows changing how struct fields are documented.
+func (p *Parser) ParseWithOptions(goType reflect.Type, options ParseOptions) (Docs, []string, error) {
+	return p.ParseContext(context.Background(), goType, options)
+}
+
+// parseState collects the results of a single [Parser.Parse] call.
+type parseState struct {
+	// ctx stops loading packages, see [Parser.ParseContext].
+	ctx      context.Context
+	docs     Docs
+	warnings []string
+	// parsing holds the struct and map types whose fields or elements are being parsed,
+	// so that recursive types are not parsed again.
+	parsing map[reflect.Type]Doc
+	options ParseOptions
 }
 
 func (d Docs) add(doc Doc) {
 	d[doc.Key()] = doc
 }
 
-func (p *Parser) parse(goType reflect.Type, docs Docs) (*Doc, error) {
-	for goType.Kind() == reflect.Pointer || goType.Kind() == reflect.Slice {
+func (p *Parser) parse(goType reflect.Type, state *parseState) (*Doc, error) {
+	for slices.Contains([]reflect.Kind{reflect.Pointer, reflect.Slice, reflect.Array}, goType.Kind()) {
 		goType = goType.Elem()
 	}
 
+	if doc, ok := state.parsing[goType]; ok {
+		// The type's documentation is added to the docs once its fields are parsed.
+		return &doc, nil
+	}
+
 	name := goType.Name()
 	pkgPath := goType.PkgPath()
 	typeDoc := Doc{
@@ -112,37 +385,85 @@ func (p *Parser) parse(goType reflect.Type, docs Docs) (*Doc, error) {
 		Package: pkgPath,
 	}
 
+	if goType.Kind() == reflect.Map {
+		if err := p.parseMapTypes(goType, state); err != nil {
+			return nil, err
+		}
+	}
 	if pkgPath == "" {
 		return &typeDoc, nil
 	}
 
-	pkg, decl, err := p.getTypeDeclarationInfo(pkgPath, name)
+	// Instantiated generic types are named with their type arguments, e.g. "Page[int]",
+	// while their declarations are not.
+	declName, _, _ := strings.Cut(name, "[")
+	pkg, decl, err := p.getTypeDeclarationInfo(state.ctx, pkgPath, declName)
+	if ctxErr := state.ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
 	if err != nil {
-		return nil, err
+		// The type is still traversed, so that documentation of its fields' types is not lost.
+		state.warnings = append(state.warnings, fmt.Sprintf("type %s is not documented: %v", goType, err))
+		if goType.Kind() == reflect.Struct {
+			if err = p.parseStructFields(goType, &typeDoc, nil, nil, state); err != nil {
+				return nil, err
+			}
+		}
+		return &typeDoc, nil
 	}
-	typeDoc.Doc = docCommentToMarkdown(pkg.commentParser, pkg.pkg.PkgPath, decl.Doc.Text())
+	docLinkBaseURL := state.options.DocLinkBaseURL
+	pkg.setDocComment(&typeDoc, decl.Doc.Text(), docLinkBaseURL)
 
+	if goType.Kind() == reflect.Interface {
+		typeDoc.Methods = p.parseInterfaceMethods(state.ctx, pkg, name, docLinkBaseURL)
+	} else {
+		typeDoc.DeclaredMethods = p.parseDeclaredMethods(pkg, declName, docLinkBaseURL)
+	}
+	if goType.Kind() != reflect.Interface && goType.Kind() != reflect.Struct {
+		typeDoc.EnumConstants = parseEnumConstants(pkg, declName, docLinkBaseURL)
+	}
 	if goType.Kind() != reflect.Struct {
-		docs.add(typeDoc)
+		state.docs.add(typeDoc)
 		return &typeDoc, nil
 	}
 
-	if err := p.parseStructFields(goType, &typeDoc, pkg, decl, docs); err != nil {
+	structType, err := extractStructType(decl, typeDoc.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err = p.parseStructFields(goType, &typeDoc, pkg, structType, state); err != nil {
 		return nil, err
 	}
 
-	docs.add(typeDoc)
+	state.docs.add(typeDoc)
 	return &typeDoc, nil
 }
 
-func (p *Parser) getTypeDeclarationInfo(pkgPath, name string) (*goPackage, *ast.GenDecl, error) {
-	pkg := p.pkgs[pkgPath]
+// parseMapTypes parses the documentation of the map's key and value types.
+func (p *Parser) parseMapTypes(goType reflect.Type, state *parseState) error {
+	state.parsing[goType] = Doc{Name: goType.Name(), Package: goType.PkgPath()}
+	defer delete(state.parsing, goType)
+
+	if _, err := p.parse(goType.Key(), state); err != nil {
+		return fmt.Errorf("failed to parse %s map key: %w", goType, err)
+	}
+	if _, err := p.parse(goType.Elem(), state); err != nil {
+		return fmt.Errorf("failed to parse %s map value: %w", goType, err)
+	}
+	return nil
+}
+
+func (p *Parser) getTypeDeclarationInfo(
+	ctx context.Context,
+	pkgPath, name string,
+) (*goPackage, *ast.GenDecl, error) {
+	pkg, err := p.getPackage(ctx, pkgPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load %s package for type %s: %w", pkgPath, name, err)
+	}
 	if pkg == nil {
 		return nil, nil, fmt.Errorf("could not find %s package for type %s", pkgPath, name)
 	}
-	if pkg.commentParser == nil {
-		pkg.commentParser = p.newCommentParserForPackage(pkg.pkg)
-	}
 
 	decl, err := findTypeDeclaration(pkg, name)
 	if err != nil {
@@ -152,23 +473,28 @@ func (p *Parser) getTypeDeclarationInfo(pkgPath, name string) (*goPackage, *ast.
 	return pkg, decl, nil
 }
 
+// parseStructFields parses the documentation of the struct's fields.
+// If structType is nil, the fields' types are parsed, but the fields themselves are not documented.
+// Fields of recursive types, like a tree node referencing its children,
+// are documented with the type's documentation only.
 func (p *Parser) parseStructFields(
 	goType reflect.Type,
 	typeDoc *Doc,
 	pkg *goPackage,
-	decl *ast.GenDecl,
-	docs Docs,
+	structType *ast.StructType,
+	state *parseState,
 ) error {
-	structType, err := extractStructType(decl, typeDoc.Name)
-	if err != nil {
-		return err
+	state.parsing[goType] = *typeDoc
+	defer delete(state.parsing, goType)
+
+	var astFieldsByName map[string]*ast.Field
+	if structType != nil {
+		astFieldsByName = buildASTFieldMap(structType)
 	}
 
 	typeDoc.StructFields = make(Docs, goType.NumField())
-	astFieldsByName := buildASTFieldMap(structType)
-
 	for field := range goType.Fields() {
-		if err := p.parseStructField(field, typeDoc, pkg, astFieldsByName, docs); err != nil {
+		if err := p.parseStructField(field, typeDoc, pkg, astFieldsByName, state); err != nil {
 			return err
 		}
 	}
@@ -209,6 +535,43 @@ func buildASTFieldMap(structType *ast.StructType) map[string]*ast.Field {
 	return astFieldsByName
 }
 
+// anonymousStructType returns the anonymous struct type goType is declared with, if any,
+// e.g. struct { Host string } for []struct { Host string }, along with its declaration in expr.
+// The declaration is nil if expr is nil or does not correspond to goType.
+func anonymousStructType(goType reflect.Type, expr ast.Expr) (reflect.Type, *ast.StructType) {
+	for {
+		switch goType.Kind() {
+		case reflect.Pointer:
+			star, _ := expr.(*ast.StarExpr)
+			expr = nil
+			if star != nil {
+				expr = star.X
+			}
+		case reflect.Slice, reflect.Array:
+			array, _ := expr.(*ast.ArrayType)
+			expr = nil
+			if array != nil {
+				expr = array.Elt
+			}
+		case reflect.Map:
+			mapType, _ := expr.(*ast.MapType)
+			expr = nil
+			if mapType != nil {
+				expr = mapType.Value
+			}
+		case reflect.Struct:
+			if goType.Name() != "" {
+				return nil, nil
+			}
+			structType, _ := expr.(*ast.StructType)
+			return goType, structType
+		default:
+			return nil, nil
+		}
+		goType = goType.Elem()
+	}
+}
+
 func embeddedFieldName(expr ast.Expr) string {
 	switch typ := expr.(type) {
 	case *ast.Ident:
@@ -217,6 +580,12 @@ func embeddedFieldName(expr ast.Expr) string {
 		return typ.Sel.Name
 	case *ast.StarExpr:
 		return embeddedFieldName(typ.X)
+	case *ast.IndexExpr:
+		// Instantiated generic type, e.g. Box[T].
+		return embeddedFieldName(typ.X)
+	case *ast.IndexListExpr:
+		// Instantiated generic type with multiple type arguments, e.g. Pair[K, V].
+		return embeddedFieldName(typ.X)
 	default:
 		return ""
 	}
@@ -227,22 +596,52 @@ func (p *Parser) parseStructField(
 	typeDoc *Doc,
 	pkg *goPackage,
 	astFieldsByName map[string]*ast.Field,
-	docs Docs,
+	state *parseState,
 ) error {
-	fieldDoc, err := p.parse(goTypeField.Type, docs)
+	fieldDoc, err := p.parse(goTypeField.Type, state)
 	if err != nil {
 		return fmt.Errorf("failed to parse %s struct field %s: %w", typeDoc.Name, goTypeField.Name, err)
 	}
 
-	fieldName := getStructFieldName(goTypeField)
+	if isPromotedStructField(goTypeField, state.options) {
+		for _, name := range fieldDoc.FieldOrder {
+			if _, exists := typeDoc.StructFields[name]; !exists {
+				typeDoc.StructFields[name] = fieldDoc.StructFields[name]
+				typeDoc.FieldOrder = append(typeDoc.FieldOrder, name)
+			}
+		}
+		return nil
+	}
+
+	fieldName := getStructFieldName(goTypeField, state.options)
 	if fieldName == "" {
 		return nil
 	}
 
-	if astField, ok := astFieldsByName[goTypeField.Name]; ok {
-		fieldDoc.Doc = docCommentToMarkdown(pkg.commentParser, pkg.pkg.PkgPath, astField.Doc.Text())
+	astField, hasASTField := astFieldsByName[goTypeField.Name]
+	if hasASTField {
+		text := astField.Doc.Text()
+		if text == "" && state.options.TrailingComments {
+			text = astField.Comment.Text()
+		}
+		pkg.setDocComment(fieldDoc, text, state.options.DocLinkBaseURL)
+		fieldDoc.SourcePos = p.sourcePos(pkg, astField.Pos())
+	}
+	// Anonymous struct types have no declaration of their own,
+	// their fields are documented with the field's declaration instead.
+	var astFieldType ast.Expr
+	if hasASTField {
+		astFieldType = astField.Type
+	}
+	if structGoType, structType := anonymousStructType(goTypeField.Type, astFieldType); structGoType != nil {
+		if err = p.parseStructFields(structGoType, fieldDoc, pkg, structType, state); err != nil {
+			return fmt.Errorf("failed to parse %s struct field %s: %w", typeDoc.Name, goTypeField.Name, err)
+		}
 	}
 
+	if _, exists := typeDoc.StructFields[fieldName]; !exists {
+		typeDoc.FieldOrder = append(typeDoc.FieldOrder, fieldName)
+	}
 	typeDoc.StructFields[fieldName] = *fieldDoc
 	return nil
 }
@@ -267,12 +666,222 @@ func findTypeDeclaration(pkg *goPackage, name string) (*ast.GenDecl, error) {
 	return nil, fmt.Errorf("could not find %s.%s declaration", pkg.pkg.Name, name)
 }
 
-func docCommentToMarkdown(parser *comment.Parser, pkg, text string) string {
-	if text == "" {
-		return ""
+// parseInterfaceMethods returns the methods of the named interface declared in pkg.
+// Method comments are looked up in the packages which declare the methods,
+// as these might come from interfaces embedded from other packages.
+func (p *Parser) parseInterfaceMethods(ctx context.Context, pkg *goPackage, name, docLinkBaseURL string) []Method {
+	iface, ok := pkg.pkg.Types.Scope().Lookup(name).Type().Underlying().(*types.Interface)
+	if !ok {
+		return nil
+	}
+	qualifier := types.RelativeTo(pkg.pkg.Types)
+	methods := make([]Method, 0, iface.NumMethods())
+	for fn := range iface.Methods() {
+		signature := types.TypeString(fn.Type(), qualifier)
+		method := Method{
+			Doc:       Doc{Name: fn.Name()},
+			Signature: fn.Name() + strings.TrimPrefix(signature, "func"),
+		}
+		if fn.Pkg() != nil {
+			if methodPkg, _ := p.getPackage(ctx, fn.Pkg().Path()); methodPkg != nil {
+				text := findMethodComment(methodPkg, methodPos(pkg, methodPkg, fn))
+				methodPkg.setDocComment(&method.Doc, text, docLinkBaseURL)
+			}
+		}
+		methods = append(methods, method)
+	}
+	return methods
+}
+
+// parseDeclaredMethods parses the exported methods declared with the named type as their receiver.
+func (p *Parser) parseDeclaredMethods(pkg *goPackage, name, docLinkBaseURL string) []Method {
+	named, ok := pkg.pkg.Types.Scope().Lookup(name).Type().(*types.Named)
+	if !ok {
+		return nil
+	}
+	qualifier := types.RelativeTo(pkg.pkg.Types)
+	var methods []Method
+	for fn := range named.Methods() {
+		if !fn.Exported() {
+			continue
+		}
+		signature := types.TypeString(fn.Signature(), qualifier)
+		method := Method{
+			Doc:       Doc{Name: fn.Name()},
+			Signature: fn.Name() + strings.TrimPrefix(signature, "func"),
+		}
+		pkg.setDocComment(&method.Doc, findMethodComment(pkg, fn.Pos()), docLinkBaseURL)
+		methods = append(methods, method)
 	}
-	typeDoc := parser.Parse(text)
-	printer := comment.Printer{
+	slices.SortFunc(methods, func(a, b Method) int { return strings.Compare(a.Name, b.Name) })
+	return methods
+}
+
+// parseEnumConstants parses the exported constants of the named type declared in pkg.
+func parseEnumConstants(pkg *goPackage, name, docLinkBaseURL string) []EnumConstant {
+	typeName, ok := pkg.pkg.Types.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil
+	}
+	var constants []EnumConstant
+	for _, file := range pkg.pkg.Syntax {
+		for _, decl := range file.Decls {
+			if genDecl, ok := decl.(*ast.GenDecl); ok && genDecl.Tok == token.CONST {
+				constants = append(constants, pkg.parseConstSpecs(genDecl, typeName.Type(), docLinkBaseURL)...)
+			}
+		}
+	}
+	return constants
+}
+
+// parseConstSpecs parses the exported constants of typ declared in the const declaration.
+func (g *goPackage) parseConstSpecs(decl *ast.GenDecl, typ types.Type, docLinkBaseURL string) []EnumConstant {
+	var constants []EnumConstant
+	for _, spec := range decl.Specs {
+		valueSpec, ok := spec.(*ast.ValueSpec)
+		if !ok {
+			continue
+		}
+		text := valueSpec.Doc.Text()
+		if text == "" {
+			text = valueSpec.Comment.Text()
+		}
+		for _, ident := range valueSpec.Names {
+			obj, ok := g.pkg.TypesInfo.Defs[ident].(*types.Const)
+			if !ok || !obj.Exported() || !types.Identical(obj.Type(), typ) {
+				continue
+			}
+			enumConstant := EnumConstant{Doc: Doc{Name: obj.Name()}, Value: constantValue(obj.Val())}
+			g.setDocComment(&enumConstant.Doc, text, docLinkBaseURL)
+			constants = append(constants, enumConstant)
+		}
+	}
+	return constants
+}
+
+// constantValue returns the constant's value, with string constants unquoted.
+func constantValue(value constant.Value) string {
+	if value.Kind() == constant.String {
+		return constant.StringVal(value)
+	}
+	return value.ExactString()
+}
+
+// findMethodComment returns the comment of the interface method or the method declaration at pos.
+func findMethodComment(pkg *goPackage, pos token.Pos) string {
+	for _, file := range pkg.pkg.Syntax {
+		if file.FileStart > pos || pos >= file.FileEnd {
+			continue
+		}
+		path, _ := astutil.PathEnclosingInterval(file, pos, pos)
+		for _, n := range path {
+			switch n := n.(type) {
+			case *ast.Field:
+				return n.Doc.Text()
+			case *ast.FuncDecl:
+				return n.Doc.Text()
+			}
+		}
+	}
+	return ""
+}
+
+// methodPos returns the position of the interface method fn of pkg in methodPkg, which declares it.
+// For lazy parsers, which read the types of dependencies from export data, their file sets differ
+// and export data positions lack columns, hence the method is matched by its file, line, and name.
+func methodPos(pkg, methodPkg *goPackage, fn *types.Func) token.Pos {
+	if pkg.pkg.Fset == methodPkg.pkg.Fset {
+		return fn.Pos()
+	}
+	position := pkg.pkg.Fset.Position(fn.Pos())
+	fset := methodPkg.pkg.Fset
+	for _, file := range methodPkg.pkg.Syntax {
+		if filepath.Base(fset.File(file.Pos()).Name()) != filepath.Base(position.Filename) {
+			continue
+		}
+		pos := token.NoPos
+		ast.Inspect(file, func(n ast.Node) bool {
+			field, ok := n.(*ast.Field)
+			if !ok || pos.IsValid() {
+				return !pos.IsValid()
+			}
+			for _, ident := range field.Names {
+				if ident.Name == fn.Name() && fset.Position(ident.Pos()).Line == position.Line {
+					pos = ident.Pos()
+				}
+			}
+			return true
+		})
+		return pos
+	}
+	return token.NoPos
+}
+
+// sourcePos returns the position of pos in the package's files.
+func (p *Parser) sourcePos(pkg *goPackage, pos token.Pos) SourcePos {
+	position := pkg.pkg.Fset.Position(pos)
+	file := position.Filename
+	if relativePath, err := filepath.Rel(p.dir, file); err == nil && filepath.IsLocal(relativePath) {
+		file = filepath.ToSlash(relativePath)
+	}
+	return SourcePos{File: file, Line: position.Line}
+}
+
+// addPackageDoc sets [Doc.PackageDoc] of the documented goType.
+func (p *Parser) addPackageDoc(goType reflect.Type, state *parseState) {
+	for slices.Contains([]reflect.Kind{reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map}, goType.Kind()) {
+		goType = goType.Elem()
+	}
+	typeDoc, ok := state.docs[Doc{Name: goType.Name(), Package: goType.PkgPath()}.Key()]
+	if !ok {
+		return
+	}
+	pkg, err := p.getPackage(state.ctx, goType.PkgPath())
+	if err != nil || pkg == nil {
+		return
+	}
+	typeDoc.PackageDoc = pkg.packageDoc(state.options.DocLinkBaseURL)
+	state.docs.add(typeDoc)
+}
+
+// packageDoc returns the package comment, which is collected from all the package's files, like [go/doc] does.
+// It returns nil if there is no package comment.
+func (g *goPackage) packageDoc(docLinkBaseURL string) *Doc {
+	var texts []string
+	for _, file := range g.pkg.Syntax {
+		if text := file.Doc.Text(); text != "" {
+			texts = append(texts, text)
+		}
+	}
+	if len(texts) == 0 {
+		return nil
+	}
+	doc := &Doc{Name: g.pkg.Name, Package: g.pkg.PkgPath}
+	g.setDocComment(doc, strings.Join(texts, "\n"), docLinkBaseURL)
+	return doc
+}
+
+// setDocComment parses text and sets it as the doc's documentation,
+// in its raw, Markdown, HTML, and plain text form.
+// Doc links point to the documentation hosted at docLinkBaseURL.
+func (g *goPackage) setDocComment(doc *Doc, text, docLinkBaseURL string) {
+	doc.RawDoc = text
+	if text == "" {
+		doc.Comment = nil
+		doc.Doc = ""
+		doc.HTMLDoc = ""
+		doc.TextDoc = ""
+		return
+	}
+	doc.Comment = g.commentParser.Parse(text)
+	printer := newCommentPrinter(g.pkg.PkgPath, docLinkBaseURL)
+	doc.Doc = string(printer.Markdown(doc.Comment))
+	doc.HTMLDoc = string(printer.HTML(doc.Comment))
+	doc.TextDoc = string(printer.Text(doc.Comment))
+}
+
+func newCommentPrinter(pkg, docLinkBaseURL string) *comment.Printer {
+	return &comment.Printer{
 		DocLinkURL: func(link *comment.DocLink) string {
 			if link.ImportPath == "" {
 				link.ImportPath = pkg
@@ -280,15 +889,18 @@ func docCommentToMarkdown(parser *comment.Parser, pkg, text string) string {
 			return link.DefaultURL(docLinkBaseURL)
 		},
 	}
-	return string(printer.Markdown(typeDoc))
 }
 
 func (p *Parser) newCommentParserForPackage(currentPackage *packages.Package) *comment.Parser {
 	return &comment.Parser{
 		LookupPackage: func(name string) (importPath string, ok bool) {
-			for _, pkg := range p.pkgs {
-				if pkg.pkg.Name == name {
-					return pkg.pkg.PkgPath, true
+			if importPath, ok = p.findPackageByName(name); ok {
+				return importPath, true
+			}
+			// Lazy parsers might not have loaded the imported packages yet.
+			for _, imported := range currentPackage.Types.Imports() {
+				if imported.Name() == name {
+					return imported.Path(), true
 				}
 			}
 			return "", false
@@ -321,44 +933,64 @@ func (p *Parser) collectAllPackages(pkgs []*packages.Package) {
 		if _, exists := p.pkgs[pkg.PkgPath]; exists {
 			continue
 		}
-		p.pkgs[pkg.PkgPath] = &goPackage{pkg: pkg}
+		p.pkgs[pkg.PkgPath] = p.newGoPackage(pkg)
 		p.collectAllPackages(slices.Collect(maps.Values(pkg.Imports)))
 	}
 }
 
+// checkForPackageErrors returns [LoadErrors] reported for pkgs and their dependencies, or nil if there are none.
 func checkForPackageErrors(pkgs []*packages.Package) error {
-	var errs []error
+	var errs LoadErrors
 	packages.Visit(pkgs, func(pkg *packages.Package) bool {
 		for _, pkgErr := range pkg.Errors {
-			errs = append(errs, fmt.Errorf("package %s has reported an error: %w", pkg.PkgPath, pkgErr))
+			errs = append(errs, &PackageLoadError{Path: pkg.PkgPath, Err: pkgErr})
 		}
 		mod := pkg.Module
 		if mod != nil && mod.Error != nil {
-			errs = append(errs, fmt.Errorf("module %s has error: %s", mod.Path, mod.Error.Err))
+			errs = append(errs, &ModuleLoadError{Path: mod.Path, Err: errors.New(mod.Error.Err)})
 		}
 		return true
 	}, nil)
-
-	switch len(errs) {
-	case 0:
+	if len(errs) == 0 {
 		return nil
-	case 1:
-		return errs[0]
-	default:
-		return fmt.Errorf("encountered %d errors while loading packages: %w", len(errs), errors.Join(errs...))
 	}
+	return errs
 }
 
-func getStructFieldName(field reflect.StructField) string {
-	if !field.IsExported() {
-		return ""
+// isPromotedStructField reports whether the fields of an embedded struct (or struct pointer)
+// are promoted to the parent struct when encoded, which is the case when the field has no name in the tag.
+// Exported embedded structs are not promoted if [ParseOptions.NestEmbedded] is set.
+func isPromotedStructField(field reflect.StructField, options ParseOptions) bool {
+	if !field.Anonymous {
+		return false
 	}
-	tagName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
-	if tagName == "" {
-		return field.Name
+	if !field.IsExported() && field.Type.Kind() == reflect.Pointer {
+		return false
+	}
+	if field.IsExported() && options.NestEmbedded {
+		return false
+	}
+	if name, _, _ := strings.Cut(field.Tag.Get(options.TagName), ","); name != "" {
+		return false
 	}
-	if tagName == "-" {
+	typ := field.Type
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return typ.Kind() == reflect.Struct
+}
+
+func getStructFieldName(field reflect.StructField, options ParseOptions) string {
+	name, _, _ := strings.Cut(field.Tag.Get(options.TagName), ",")
+	switch {
+	case !field.IsExported() || name == "-":
+		if options.IncludeHidden {
+			return field.Name
+		}
 		return ""
+	case name == "":
+		return field.Name
+	default:
+		return name
 	}
-	return tagName
 }
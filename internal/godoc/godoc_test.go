@@ -1,7 +1,10 @@
 package godoc
 
 import (
+	"context"
 	"go/ast"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -14,7 +17,7 @@ import (
 )
 
 func TestNewParser(t *testing.T) {
-	parser, err := NewParser()
+	parser, err := NewParser(t.Context())
 
 	require.NoError(t, err)
 	require.NotNil(t, parser)
@@ -22,9 +25,106 @@ func TestNewParser(t *testing.T) {
 	assert.Contains(t, parser.pkgs, testModelsPackage)
 }
 
+func TestNewParser_CanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	parser, err := NewParser(ctx)
+
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, parser)
+}
+
+func TestNewParser_Workspace(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(path, content string) {
+		t.Helper()
+		path = filepath.Join(dir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	writeFile("go.work", "go 1.26\n\nuse (\n\t./config\n\t./shared\n)\n")
+	writeFile("shared/go.mod", "module example.com/shared\n\ngo 1.26\n")
+	writeFile("shared/shared.go",
+		"package shared\n\n// Duration is shared.\ntype Duration int\n")
+	writeFile("config/go.mod",
+		"module example.com/config\n\ngo 1.26\n\nrequire example.com/shared v0.0.0\n")
+	writeFile("config/config.go",
+		"package config\n\nimport \"example.com/shared\"\n\ntype Config struct {\n\tTimeout shared.Duration\n}\n")
+	t.Setenv("GOWORK", "")
+	// Workspaces do not support the -mod=mod flag.
+	t.Setenv("GOFLAGS", "")
+	t.Chdir(filepath.Join(dir, "config"))
+
+	t.Run("discovered modules", func(t *testing.T) {
+		parser, err := NewParser(t.Context())
+
+		require.NoError(t, err)
+		assert.Contains(t, parser.pkgs, "example.com/config")
+		assert.Contains(t, parser.pkgs, "example.com/shared")
+		assert.Equal(t, dir, parser.dir)
+	})
+
+	t.Run("explicit module roots", func(t *testing.T) {
+		parser, err := NewParser(t.Context(), filepath.Join(dir, "shared"))
+
+		require.NoError(t, err)
+		assert.NotContains(t, parser.pkgs, "example.com/config")
+		assert.Contains(t, parser.pkgs, "example.com/shared")
+		assert.Equal(t, filepath.Join(dir, "shared"), parser.dir)
+	})
+
+	t.Run("lazy parser", func(t *testing.T) {
+		parser, err := NewLazyParser()
+		require.NoError(t, err)
+
+		pkg, err := parser.getPackage(context.Background(), "example.com/shared")
+
+		require.NoError(t, err)
+		require.NotNil(t, pkg)
+		assert.Equal(t, "shared", pkg.pkg.Name)
+	})
+
+	t.Run("invalid module root", func(t *testing.T) {
+		_, err := NewParser(t.Context(), dir)
+
+		require.EqualError(t, err, "failed to find module root: invalid module root "+dir+": go.mod not found")
+	})
+}
+
+func Test_commonDir(t *testing.T) {
+	root := string(filepath.Separator)
+	tests := map[string]struct {
+		roots    []string
+		expected string
+	}{
+		"single root": {
+			roots:    []string{filepath.Join(root, "repo")},
+			expected: filepath.Join(root, "repo"),
+		},
+		"sibling roots": {
+			roots:    []string{filepath.Join(root, "repo", "a"), filepath.Join(root, "repo", "b", "c")},
+			expected: filepath.Join(root, "repo"),
+		},
+		"nested roots": {
+			roots:    []string{filepath.Join(root, "repo", "a"), filepath.Join(root, "repo")},
+			expected: filepath.Join(root, "repo"),
+		},
+		"unrelated roots": {
+			roots:    []string{filepath.Join(root, "a"), filepath.Join(root, "b")},
+			expected: root,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, commonDir(test.roots))
+		})
+	}
+}
+
 func TestParser_Parse(t *testing.T) {
 	parser := newTestParser(t)
-	docs, err := parser.Parse(reflect.TypeFor[testmodels.Teacher]())
+	docs, _, err := parser.Parse(reflect.TypeFor[testmodels.Teacher]())
 	require.NoError(t, err)
 
 	t.Run("type documentation", func(t *testing.T) {
@@ -44,6 +144,16 @@ func TestParser_Parse(t *testing.T) {
 		assert.Contains(t, teacherDoc.StructFields, "students")
 	})
 
+	t.Run("struct field order", func(t *testing.T) {
+		teacherDoc, found := docs[testModelsPackage+".Teacher"]
+		require.True(t, found)
+		assert.Equal(
+			t,
+			[]string{"name", "hobby", "age", "students", "university", "NoTag", "stringer"},
+			teacherDoc.FieldOrder,
+		)
+	})
+
 	t.Run("nested type", func(t *testing.T) {
 		studentDoc, found := docs[testModelsPackage+".Student"]
 		require.True(t, found)
@@ -68,7 +178,7 @@ func TestParser_Parse(t *testing.T) {
 	t.Run("deprecated marker", func(t *testing.T) {
 		studentsField, found := reflect.TypeFor[testmodels.Teacher]().FieldByName("Students")
 		require.True(t, found)
-		studentDocs, err := parser.Parse(studentsField.Type.Elem())
+		studentDocs, _, err := parser.Parse(studentsField.Type.Elem())
 		require.NoError(t, err)
 
 		studentDoc, found := studentDocs[testModelsPackage+".Student"]
@@ -78,28 +188,332 @@ func TestParser_Parse(t *testing.T) {
 
 	t.Run("nested pointer and slice type", func(t *testing.T) {
 		typ := reflect.PointerTo(reflect.SliceOf(reflect.PointerTo(reflect.TypeFor[testmodels.Teacher]())))
-		nestedDocs, err := parser.Parse(typ)
+		nestedDocs, _, err := parser.Parse(typ)
 		require.NoError(t, err)
 		assert.Contains(t, nestedDocs, testModelsPackage+".Teacher")
 	})
 
+	t.Run("array type", func(t *testing.T) {
+		arrayDocs, _, err := parser.Parse(reflect.TypeFor[[2]testmodels.Teacher]())
+		require.NoError(t, err)
+		assert.Contains(t, arrayDocs, testModelsPackage+".Teacher")
+	})
+
+	t.Run("embedded struct pointer", func(t *testing.T) {
+		residentDocs, _, err := parser.Parse(reflect.TypeFor[testmodels.Resident]())
+		require.NoError(t, err)
+
+		residentDoc, found := residentDocs[testModelsPackage+".Resident"]
+		require.True(t, found)
+		assert.NotContains(t, residentDoc.StructFields, "Address")
+		assert.Contains(t, residentDoc.StructFields, "name")
+		assert.Contains(t, residentDoc.StructFields, "state")
+		cityDoc, found := residentDoc.StructFields["city"]
+		require.True(t, found)
+		assert.Equal(t, "City is the name of the city.\n", cityDoc.Doc)
+		assert.Equal(t, []string{"name", "city", "state"}, residentDoc.FieldOrder)
+	})
+
+	t.Run("nested embedded struct", func(t *testing.T) {
+		residentDocs, _, err := parser.ParseWithOptions(
+			reflect.TypeFor[testmodels.Resident](),
+			ParseOptions{NestEmbedded: true},
+		)
+		require.NoError(t, err)
+
+		residentDoc := residentDocs[testModelsPackage+".Resident"]
+		assert.Equal(t, []string{"name", "Address"}, residentDoc.FieldOrder)
+		assert.Equal(t, "Address is where the resident lives.\n", residentDoc.StructFields["Address"].RawDoc)
+		assert.Contains(t, residentDocs, testModelsPackage+".Address")
+	})
+
+	t.Run("anonymous struct fields", func(t *testing.T) {
+		pipelineDocs, _, err := parser.Parse(reflect.TypeFor[testmodels.Pipeline]())
+		require.NoError(t, err)
+
+		pipelineDoc := pipelineDocs[testModelsPackage+".Pipeline"]
+		configDoc := pipelineDoc.StructFields["config"]
+		assert.Equal(t, "Config configures the pipeline.\n", configDoc.RawDoc)
+		assert.Equal(t, []string{"host", "retry"}, configDoc.FieldOrder)
+		assert.Equal(t, "Host runs the pipeline.\n", configDoc.StructFields["host"].RawDoc)
+		assert.Equal(t, SourcePos{File: "internal/testmodels/pipeline.go", Line: 8},
+			configDoc.StructFields["host"].SourcePos)
+		retryDoc := configDoc.StructFields["retry"]
+		assert.Equal(t, "Attempts limits the retries.\n", retryDoc.StructFields["attempts"].RawDoc)
+		stepsDoc := pipelineDoc.StructFields["steps"]
+		assert.Equal(t, "Name identifies the step.\n", stepsDoc.StructFields["name"].RawDoc)
+		assert.Equal(t, "Owner is responsible for the step.\n", stepsDoc.StructFields["owner"].RawDoc)
+		assert.Contains(t, pipelineDocs, testModelsPackage+".Student")
+	})
+
+	t.Run("source positions", func(t *testing.T) {
+		teacherDoc := docs[testModelsPackage+".Teacher"]
+		assert.Equal(t, SourcePos{File: "internal/testmodels/models.go", Line: 18}, teacherDoc.StructFields["name"].SourcePos)
+		assert.Zero(t, teacherDoc.SourcePos)
+	})
+
+	t.Run("package doc", func(t *testing.T) {
+		packageDoc := docs[testModelsPackage+".Teacher"].PackageDoc
+		require.NotNil(t, packageDoc)
+		assert.Equal(t, "testmodels", packageDoc.Name)
+		assert.Equal(t, testModelsPackage, packageDoc.Package)
+		assert.Equal(t,
+			"Package testmodels declares the models documented in tests.\nSee [Teacher] for the most common one.\n",
+			packageDoc.RawDoc)
+		assert.Contains(t, packageDoc.Doc, "(https://pkg.go.dev/"+testModelsPackage+"#Teacher)")
+		assert.Nil(t, docs[testModelsPackage+".Student"].PackageDoc)
+		assert.Nil(t, docs[moreModelsPackage+".University"].PackageDoc)
+	})
+
+	t.Run("trailing comments", func(t *testing.T) {
+		teacherDoc := docs[testModelsPackage+".Teacher"]
+		assert.Empty(t, teacherDoc.StructFields["age"].RawDoc)
+
+		teacherDocs, _, err := parser.ParseWithOptions(
+			reflect.TypeFor[testmodels.Teacher](),
+			ParseOptions{TrailingComments: true},
+		)
+		require.NoError(t, err)
+		teacherDoc = teacherDocs[testModelsPackage+".Teacher"]
+		assert.Equal(t,
+			"Age is the age of the teacher. This is not a valid doc comment.\n",
+			teacherDoc.StructFields["age"].RawDoc)
+		assert.Equal(t,
+			"University is the university of the teacher.\n",
+			teacherDoc.StructFields["university"].RawDoc)
+		assert.Equal(t, "Name is the name of the teacher.\n", teacherDoc.StructFields["name"].RawDoc)
+		assert.Empty(t, teacherDoc.StructFields["hobby"].RawDoc)
+	})
+
+	t.Run("hidden fields", func(t *testing.T) {
+		typ := reflect.TypeFor[testmodels.Credentials]()
+		credentialsDocs, _, err := parser.Parse(typ)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"user"}, credentialsDocs[testModelsPackage+".Credentials"].FieldOrder)
+
+		credentialsDocs, _, err = parser.ParseWithOptions(typ, ParseOptions{IncludeHidden: true})
+		require.NoError(t, err)
+		credentialsDoc := credentialsDocs[testModelsPackage+".Credentials"]
+		assert.Equal(t, []string{"user", "Password", "Session", "token"}, credentialsDoc.FieldOrder)
+		assert.Equal(t, "token is the cached access token.\n", credentialsDoc.StructFields["token"].RawDoc)
+	})
+
+	t.Run("recursive type", func(t *testing.T) {
+		listDocs, _, err := parser.Parse(reflect.TypeFor[testmodels.ListNode]())
+		require.NoError(t, err)
+
+		listDoc := listDocs[testModelsPackage+".ListNode"]
+		assert.Equal(t, []string{"value", "next"}, listDoc.FieldOrder)
+		nextDoc := listDoc.StructFields["next"]
+		assert.Equal(t, "Next is the following node, it is nil for the last node.\n", nextDoc.RawDoc)
+		assert.Empty(t, nextDoc.StructFields)
+
+		treeDocs, _, err := parser.Parse(reflect.TypeFor[testmodels.TreeNode]())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"children", "named"}, treeDocs[testModelsPackage+".TreeNode"].FieldOrder)
+	})
+
+	t.Run("map type", func(t *testing.T) {
+		mapDocs, _, err := parser.Parse(reflect.TypeFor[map[string]testmodels.Address]())
+		require.NoError(t, err)
+		assert.Contains(t, mapDocs, testModelsPackage+".Address")
+	})
+
+	t.Run("interface methods", func(t *testing.T) {
+		stringerDoc, found := docs["fmt.Stringer"]
+		require.True(t, found)
+		require.Len(t, stringerDoc.Methods, 1)
+		assert.Equal(t, "String", stringerDoc.Methods[0].Name)
+		assert.Equal(t, "String() string", stringerDoc.Methods[0].Signature)
+
+		notifierDocs, _, err := parser.Parse(reflect.TypeFor[testmodels.Notifier]())
+		require.NoError(t, err)
+		notifierDoc := notifierDocs[testModelsPackage+".Notifier"]
+		require.Len(t, notifierDoc.Methods, 2)
+		assert.Equal(t, "Notify", notifierDoc.Methods[0].Name)
+		assert.Equal(t, "Notify(recipient Person, message string) error", notifierDoc.Methods[0].Signature)
+		assert.Equal(t, "Notify sends the message to the recipient.\n", notifierDoc.Methods[0].RawDoc)
+		assert.Equal(t, "String", notifierDoc.Methods[1].Name)
+		assert.Equal(t, "String() string", notifierDoc.Methods[1].Signature)
+
+		courseDocs, _, err := parser.Parse(reflect.TypeFor[testmodels.Course]())
+		require.NoError(t, err)
+		courseDoc := courseDocs[testModelsPackage+".Course"]
+		require.Len(t, courseDoc.Methods, 2)
+		assert.Equal(t, "Enroll(student string) error", courseDoc.Methods[0].Signature)
+		assert.Equal(t, "Enroll registers the student with the given name.\n", courseDoc.Methods[0].RawDoc)
+		assert.Equal(t, "Title returns the course's title.\n", courseDoc.Methods[1].RawDoc)
+	})
+
+	t.Run("declared methods", func(t *testing.T) {
+		priceDocs, _, err := parser.Parse(reflect.TypeFor[testmodels.Price]())
+		require.NoError(t, err)
+
+		currencyDoc := priceDocs[testModelsPackage+".Currency"]
+		require.Len(t, currencyDoc.DeclaredMethods, 2)
+		assert.Equal(t, "GovydocDescription", currencyDoc.DeclaredMethods[0].Name)
+		assert.Equal(t, "Symbol", currencyDoc.DeclaredMethods[1].Name)
+		assert.Equal(t, "Symbol() string", currencyDoc.DeclaredMethods[1].Signature)
+		assert.Equal(t, "Symbol returns the currency's symbol, e.g. \"$\" for USD.\n", currencyDoc.DeclaredMethods[1].RawDoc)
+		assert.Empty(t, priceDocs[testModelsPackage+".Price"].DeclaredMethods)
+		assert.Empty(t, docs["fmt.Stringer"].DeclaredMethods)
+	})
+
+	t.Run("enum constants", func(t *testing.T) {
+		themeDocs, _, err := parser.Parse(reflect.TypeFor[testmodels.Theme]())
+		require.NoError(t, err)
+
+		modeConstants := themeDocs[testModelsPackage+".ThemeMode"].EnumConstants
+		require.Len(t, modeConstants, 2)
+		assert.Equal(t, "ThemeModeLight", modeConstants[0].Name)
+		assert.Equal(t, "light", modeConstants[0].Value)
+		assert.Equal(t, "ThemeModeLight uses dark text on a light background.\n", modeConstants[0].RawDoc)
+		assert.Equal(t, "ThemeModeDark", modeConstants[1].Name)
+		assert.Equal(t, "dark", modeConstants[1].Value)
+		assert.Equal(t, "ThemeModeDark uses light text on a dark background.\n", modeConstants[1].RawDoc)
+
+		contrastConstants := themeDocs[testModelsPackage+".Contrast"].EnumConstants
+		require.Len(t, contrastConstants, 2)
+		assert.Equal(t, "ContrastNormal", contrastConstants[0].Name)
+		assert.Equal(t, "0", contrastConstants[0].Value)
+		assert.Empty(t, contrastConstants[0].RawDoc)
+		assert.Equal(t, "1", contrastConstants[1].Value)
+		assert.Empty(t, themeDocs[testModelsPackage+".Theme"].EnumConstants)
+	})
+
+	t.Run("type without source", func(t *testing.T) {
+		// Types declared in test files are not loaded by the parser.
+		type testOnly struct {
+			Student testmodels.Student `json:"student"`
+		}
+		typ := reflect.TypeFor[testOnly]()
+		testOnlyDocs, warnings, err := parser.Parse(typ)
+		require.NoError(t, err)
+		assert.NotContains(t, testOnlyDocs, typ.PkgPath()+".testOnly")
+		assert.Contains(t, testOnlyDocs, testModelsPackage+".Student")
+		require.Len(t, warnings, 1)
+		assert.Contains(t, warnings[0], "type godoc.testOnly is not documented")
+	})
+
+	t.Run("generic types", func(t *testing.T) {
+		boxDocs, warnings, err := parser.Parse(reflect.TypeFor[testmodels.LabeledBoxes]())
+		require.NoError(t, err)
+		assert.Empty(t, warnings)
+
+		labeledDoc := boxDocs[testModelsPackage+".LabeledBoxes"]
+		assert.Equal(t, []string{"value", "label", "pair", "color"}, labeledDoc.FieldOrder)
+		assert.Equal(t, "Value is the boxed value.\n", labeledDoc.StructFields["value"].RawDoc)
+		assert.Equal(t, testModelsPackage+".Teacher", labeledDoc.StructFields["value"].Key())
+		assert.Equal(t, "Pair is the labeled pair.\n", labeledDoc.StructFields["pair"].RawDoc)
+
+		pairDoc := boxDocs[testModelsPackage+".Pair[string,"+testModelsPackage+".Student]"]
+		assert.Equal(t, "Pair holds a value with its key.\n", pairDoc.RawDoc)
+		assert.Equal(t, "string", pairDoc.StructFields["key"].Key())
+		assert.Equal(t, testModelsPackage+".Student", pairDoc.StructFields["entry"].Key())
+		assert.Equal(t, "Entry is optional.\n", pairDoc.StructFields["entry"].RawDoc)
+	})
+
+	t.Run("custom tag name", func(t *testing.T) {
+		configDocs, _, err := parser.ParseWithOptions(
+			reflect.TypeFor[testmodels.LoggingConfig](),
+			ParseOptions{TagName: "yaml"},
+		)
+		require.NoError(t, err)
+		configDoc := configDocs[testModelsPackage+".LoggingConfig"]
+		assert.Equal(t, []string{"log_level", "retries"}, configDoc.FieldOrder)
+		assert.Equal(t, "LogLevel is the minimum level of logged messages.\n", configDoc.StructFields["log_level"].RawDoc)
+	})
+
+	t.Run("examples", func(t *testing.T) {
+		teacherDocs, _, err := parser.ParseWithOptions(
+			reflect.TypeFor[[]*testmodels.Teacher](),
+			ParseOptions{Examples: true},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, []Example{
+			{
+				Name: "ExampleTeacher",
+				Code: "teacher := testmodels.Teacher{Name: \"John\"}\n" +
+					"// Teachers are introduced by their names.\n" +
+					"fmt.Println(teacher.Name)\n" +
+					"// Output: John",
+			},
+			{
+				Name: "ExampleTeacher_students",
+				Code: "teacher := testmodels.Teacher{Students: []testmodels.Student{{}, {}}}\n" +
+					"fmt.Println(len(teacher.Students))\n" +
+					"// Output: 2",
+			},
+		}, teacherDocs[testModelsPackage+".Teacher"].Examples)
+		assert.Nil(t, teacherDocs[testModelsPackage+".Student"].Examples)
+		assert.Nil(t, docs[testModelsPackage+".Teacher"].Examples)
+	})
+
 	t.Run("built-in type", func(t *testing.T) {
-		_, err := parser.Parse(reflect.TypeFor[string]())
+		_, _, err := parser.Parse(reflect.TypeFor[string]())
 		require.ErrorContains(t, err, "no documentation found")
 	})
 
 	t.Run("nil type", func(t *testing.T) {
-		_, err := parser.Parse(nil)
+		_, _, err := parser.Parse(nil)
 		require.EqualError(t, err, "type cannot be nil")
 	})
 }
 
+func TestNewLazyParser(t *testing.T) {
+	eagerParser := newTestParser(t)
+	lazyParser, err := NewLazyParser()
+	require.NoError(t, err)
+	assert.Zero(t, lazyParser.NumPackages())
+
+	for _, typ := range []reflect.Type{
+		reflect.TypeFor[testmodels.Teacher](),
+		reflect.TypeFor[testmodels.Notifier](),
+		reflect.TypeFor[testmodels.Price](),
+		reflect.TypeFor[testmodels.Course](),
+	} {
+		t.Run(typ.Name(), func(t *testing.T) {
+			expected, expectedWarnings, err := eagerParser.Parse(typ)
+			require.NoError(t, err)
+			actual, warnings, err := lazyParser.Parse(typ)
+			require.NoError(t, err)
+
+			assert.Equal(t, expected, actual)
+			assert.Equal(t, expectedWarnings, warnings)
+		})
+	}
+	assert.Less(t, lazyParser.NumPackages(), eagerParser.NumPackages())
+
+	t.Run("package which fails to load", func(t *testing.T) {
+		_, err := lazyParser.getPackage(context.Background(), "example.com/missing")
+		require.Error(t, err)
+		_, retryErr := lazyParser.getPackage(context.Background(), "example.com/missing")
+		assert.Equal(t, err, retryErr)
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		parser, err := NewLazyParser()
+		require.NoError(t, err)
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		_, _, err = parser.ParseContext(ctx, reflect.TypeFor[testmodels.Teacher](), ParseOptions{})
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Zero(t, parser.NumPackages())
+
+		// Canceled loads are not cached as failures.
+		docs, _, err := parser.Parse(reflect.TypeFor[testmodels.Teacher]())
+		require.NoError(t, err)
+		assert.Contains(t, docs, testModelsPackage+".Teacher")
+	})
+}
+
 func TestParser_ParseMultipleTypes(t *testing.T) {
 	parser := newTestParser(t)
 
-	teacherDocs, err := parser.Parse(reflect.TypeFor[testmodels.Teacher]())
+	teacherDocs, _, err := parser.Parse(reflect.TypeFor[testmodels.Teacher]())
 	require.NoError(t, err)
-	universityDocs, err := parser.Parse(reflect.TypeFor[moremodels.University]())
+	universityDocs, _, err := parser.Parse(reflect.TypeFor[moremodels.University]())
 	require.NoError(t, err)
 
 	assert.Contains(t, teacherDocs, testModelsPackage+".Teacher")
@@ -161,6 +575,11 @@ func Test_checkForPackageErrors(t *testing.T) {
 		}})
 		require.ErrorContains(t, err, "package example.com/broken has reported an error")
 		require.ErrorContains(t, err, "invalid source")
+
+		var pkgErr *PackageLoadError
+		require.ErrorAs(t, err, &pkgErr)
+		assert.Equal(t, "example.com/broken", pkgErr.Path)
+		assert.Equal(t, packages.Error{Msg: "invalid source"}, pkgErr.Err)
 	})
 
 	t.Run("multiple errors", func(t *testing.T) {
@@ -175,6 +594,28 @@ func Test_checkForPackageErrors(t *testing.T) {
 		require.ErrorContains(t, err, "encountered 2 errors while loading packages")
 		require.ErrorContains(t, err, "first error")
 		require.ErrorContains(t, err, "second error")
+
+		var loadErrs LoadErrors
+		require.ErrorAs(t, err, &loadErrs)
+		assert.Len(t, loadErrs, 2)
+	})
+
+	t.Run("module error", func(t *testing.T) {
+		t.Parallel()
+		err := checkForPackageErrors([]*packages.Package{
+			{PkgPath: "example.com/valid"},
+			{
+				PkgPath: "example.com/broken",
+				Module:  &packages.Module{Path: "example.com", Error: &packages.ModuleError{Err: "missing go.sum entry"}},
+			},
+		})
+		require.EqualError(t, err, "module example.com has error: missing go.sum entry")
+
+		var modErr *ModuleLoadError
+		require.ErrorAs(t, err, &modErr)
+		assert.Equal(t, "example.com", modErr.Path)
+		var pkgErr *PackageLoadError
+		assert.NotErrorAs(t, err, &pkgErr)
 	})
 }
 
@@ -203,6 +644,17 @@ func Test_embeddedFieldName(t *testing.T) {
 			}},
 			expected: "Remote",
 		},
+		"generic type": {
+			expr:     &ast.IndexExpr{X: ast.NewIdent("Box"), Index: ast.NewIdent("string")},
+			expected: "Box",
+		},
+		"pointer to generic type with multiple type arguments": {
+			expr: &ast.StarExpr{X: &ast.IndexListExpr{
+				X:       ast.NewIdent("Pair"),
+				Indices: []ast.Expr{ast.NewIdent("string"), ast.NewIdent("int")},
+			}},
+			expected: "Pair",
+		},
 		"unsupported expression": {
 			expr: &ast.ArrayType{Elt: ast.NewIdent("string")},
 		},
@@ -223,7 +675,7 @@ const (
 
 func newTestParser(t *testing.T) *Parser {
 	t.Helper()
-	parser, err := NewParser()
+	parser, err := NewParser(t.Context())
 	require.NoError(t, err)
 	return parser
 }
@@ -1,4 +1,4 @@
-// Package modroot locates the Go module containing the current working directory.
+// Package modroot locates the Go modules containing the current working directory.
 package modroot
 
 import (
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+
+	"golang.org/x/mod/modfile"
 )
 
 // Find returns the absolute path of the nearest directory containing a go.mod file.
@@ -15,23 +17,118 @@ func Find() (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("failed to get current working directory: %w", err)
 	}
+	dir, found, err := findUpward(filepath.Clean(dir), "go.mod")
+	if err != nil {
+		return "", err
+	}
+	if !found {
+		return "", errors.New("go.mod not found in directory tree")
+	}
+	return dir, nil
+}
+
+// FindAll returns the absolute paths of the modules the current working directory belongs to.
+// Like the go command, it uses the workspace of the nearest go.work file, or the one set with the GOWORK
+// environment variable, and returns the directories of the modules listed in its use directives.
+// Without a workspace, or if GOWORK is set to "off", it returns the module found with [Find].
+func FindAll() ([]string, error) {
+	workFile, err := findWorkFile()
+	if err != nil {
+		return nil, err
+	}
+	if workFile == "" {
+		root, err := Find()
+		if err != nil {
+			return nil, err
+		}
+		return []string{root}, nil
+	}
+	return workspaceModules(workFile)
+}
+
+// Resolve returns the absolute paths of roots, verifying that each of them contains a go.mod file.
+// If roots is empty, it returns the modules found with [FindAll].
+func Resolve(roots []string) ([]string, error) {
+	if len(roots) == 0 {
+		return FindAll()
+	}
+	resolved := make([]string, 0, len(roots))
+	for _, root := range roots {
+		absRoot, err := filepath.Abs(root)
+		if err != nil {
+			return nil, fmt.Errorf("invalid module root %s: %w", root, err)
+		}
+		info, err := os.Stat(filepath.Join(absRoot, "go.mod"))
+		if err != nil || info.IsDir() {
+			return nil, fmt.Errorf("invalid module root %s: go.mod not found", root)
+		}
+		resolved = append(resolved, absRoot)
+	}
+	return resolved, nil
+}
+
+// findWorkFile returns the absolute path of the go.work file in use, or an empty string if there is none.
+func findWorkFile() (string, error) {
+	switch gowork := os.Getenv("GOWORK"); gowork {
+	case "off":
+		return "", nil
+	case "":
+	default:
+		return filepath.Abs(gowork)
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current working directory: %w", err)
+	}
+	dir, found, err := findUpward(filepath.Clean(dir), "go.work")
+	if err != nil || !found {
+		return "", err
+	}
+	return filepath.Join(dir, "go.work"), nil
+}
+
+// workspaceModules returns the absolute paths of the modules used by the go.work file.
+func workspaceModules(workFile string) ([]string, error) {
+	data, err := os.ReadFile(workFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", workFile, err)
+	}
+	work, err := modfile.ParseWork(workFile, data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", workFile, err)
+	}
+	if len(work.Use) == 0 {
+		return nil, fmt.Errorf("no modules used by %s", workFile)
+	}
+	roots := make([]string, 0, len(work.Use))
+	for _, use := range work.Use {
+		root := filepath.FromSlash(use.Path)
+		if !filepath.IsAbs(root) {
+			root = filepath.Join(filepath.Dir(workFile), root)
+		}
+		roots = append(roots, filepath.Clean(root))
+	}
+	return roots, nil
+}
 
-	dir = filepath.Clean(dir)
+// findUpward returns the nearest directory containing the file name,
+// searching from dir toward the filesystem root.
+func findUpward(dir, name string) (string, bool, error) {
 	for {
-		goModPath := filepath.Join(dir, "go.mod")
-		info, err := os.Stat(goModPath)
+		path := filepath.Join(dir, name)
+		info, err := os.Stat(path)
 		switch {
 		case err == nil && !info.IsDir():
-			return dir, nil
+			return dir, true, nil
 		case err == nil:
 		case errors.Is(err, os.ErrNotExist):
 		default:
-			return "", fmt.Errorf("failed to stat %s: %w", goModPath, err)
+			return "", false, fmt.Errorf("failed to stat %s: %w", path, err)
 		}
 
 		parent := filepath.Dir(dir)
 		if parent == dir {
-			return "", errors.New("go.mod not found in directory tree")
+			return "", false, nil
 		}
 		dir = parent
 	}
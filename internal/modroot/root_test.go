@@ -102,3 +102,113 @@ func writeGoMod(t *testing.T, dir, module string) {
 	path := filepath.Join(dir, "go.mod")
 	require.NoError(t, os.WriteFile(path, []byte("module "+module+"\n"), 0o600))
 }
+
+func TestFindAll(t *testing.T) {
+	t.Run("workspace", func(t *testing.T) {
+		dir := t.TempDir()
+		writeGoMod(t, dir, "root")
+		for _, module := range []string{"a", "b"} {
+			require.NoError(t, os.Mkdir(filepath.Join(dir, module), 0o750))
+			writeGoMod(t, filepath.Join(dir, module), module)
+		}
+		writeGoWork(t, dir, "./a", "./b")
+		t.Setenv("GOWORK", "")
+		t.Chdir(filepath.Join(dir, "a"))
+
+		roots, err := FindAll()
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{filepath.Join(dir, "a"), filepath.Join(dir, "b")}, roots)
+	})
+
+	t.Run("workspace set with GOWORK", func(t *testing.T) {
+		dir := t.TempDir()
+		writeGoMod(t, dir, "root")
+		writeGoWork(t, dir, ".")
+		t.Setenv("GOWORK", filepath.Join(dir, "go.work"))
+		t.Chdir(t.TempDir())
+
+		roots, err := FindAll()
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{dir}, roots)
+	})
+
+	t.Run("workspace disabled", func(t *testing.T) {
+		dir := t.TempDir()
+		writeGoWork(t, dir, "./a")
+		writeGoMod(t, dir, "root")
+		t.Setenv("GOWORK", "off")
+		t.Chdir(dir)
+
+		roots, err := FindAll()
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{dir}, roots)
+	})
+
+	t.Run("no workspace", func(t *testing.T) {
+		dir := t.TempDir()
+		writeGoMod(t, dir, "root")
+		t.Setenv("GOWORK", "")
+		t.Chdir(dir)
+
+		roots, err := FindAll()
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{dir}, roots)
+	})
+
+	t.Run("empty workspace", func(t *testing.T) {
+		dir := t.TempDir()
+		writeGoWork(t, dir)
+		t.Setenv("GOWORK", "")
+		t.Chdir(dir)
+
+		_, err := FindAll()
+
+		require.EqualError(t, err, "no modules used by "+filepath.Join(dir, "go.work"))
+	})
+}
+
+func TestResolve(t *testing.T) {
+	t.Run("explicit roots", func(t *testing.T) {
+		dir := t.TempDir()
+		writeGoMod(t, dir, "root")
+		t.Chdir(filepath.Dir(dir))
+
+		roots, err := Resolve([]string{filepath.Base(dir)})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{dir}, roots)
+	})
+
+	t.Run("root without go.mod", func(t *testing.T) {
+		dir := t.TempDir()
+
+		_, err := Resolve([]string{dir})
+
+		require.EqualError(t, err, "invalid module root "+dir+": go.mod not found")
+	})
+
+	t.Run("discovered roots", func(t *testing.T) {
+		dir := t.TempDir()
+		writeGoMod(t, dir, "root")
+		t.Setenv("GOWORK", "")
+		t.Chdir(dir)
+
+		roots, err := Resolve(nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{dir}, roots)
+	})
+}
+
+func writeGoWork(t *testing.T, dir string, modules ...string) {
+	t.Helper()
+	content := "go 1.26\n"
+	for _, module := range modules {
+		content += "use " + module + "\n"
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.work"), []byte(content), 0o600))
+}
@@ -0,0 +1,14 @@
+package testmodels
+
+// Catalog lists the items on sale.
+type Catalog struct {
+	// Item is described by its implementation.
+	Item Described `json:"item"`
+	// Price of the item.
+	Price Price `json:"price"`
+}
+
+// Described is implemented by items which describe themselves.
+type Described interface {
+	GovydocDescription() string
+}
@@ -0,0 +1,3 @@
+// Package testmodels declares the models documented in tests.
+// See [Teacher] for the most common one.
+package testmodels
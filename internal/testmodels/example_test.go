@@ -0,0 +1,25 @@
+package testmodels_test
+
+import (
+	"fmt"
+
+	"github.com/nieomylnieja/govydoc/internal/testmodels"
+)
+
+func ExampleTeacher() {
+	teacher := testmodels.Teacher{Name: "John"}
+	// Teachers are introduced by their names.
+	fmt.Println(teacher.Name)
+	// Output: John
+}
+
+func ExampleTeacher_students() {
+	teacher := testmodels.Teacher{Students: []testmodels.Student{{}, {}}}
+	fmt.Println(len(teacher.Students))
+	// Output: 2
+}
+
+func ExampleStudent() {
+	fmt.Println(testmodels.Student{}.Name)
+	// Output:
+}
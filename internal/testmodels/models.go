@@ -1,7 +1,9 @@
 package testmodels
 
 import (
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/nieomylnieja/govydoc/internal/testmodels/moremodels"
 )
@@ -47,6 +49,7 @@ type SimpleStruct struct {
 
 // Address represents a physical address.
 type Address struct {
+	// City is the name of the city.
 	City  string `json:"city"`
 	State string `json:"state"`
 }
@@ -57,6 +60,124 @@ type Person struct {
 	Address Address `json:"address"`
 }
 
+// Resident represents a person living at an embedded address.
+type Resident struct {
+	Name string `json:"name"`
+	// Address is where the resident lives.
+	*Address
+}
+
+// ListNode is a node of a singly linked list.
+type ListNode struct {
+	// Value is the value stored in the node.
+	Value string `json:"value"`
+	// Next is the following node, it is nil for the last node.
+	Next *ListNode `json:"next"`
+}
+
+// TreeNode is a node of a tree, referencing its children directly and by name.
+type TreeNode struct {
+	Children []TreeNode           `json:"children"`
+	Named    map[string]*TreeNode `json:"named"`
+}
+
+// Tenant represents a person renting an address, which is embedded under a JSON name.
+type Tenant struct {
+	Name    string `json:"name"`
+	Address `json:"address"`
+}
+
+// Shipment describes a package delivery.
+//
+// # Carriers
+//
+// Supported carriers:
+//   - Postal service
+//   - [Courier] company
+//
+// Example tracking number:
+//
+//	AB123456789
+type Shipment struct {
+	// Priority is one of:
+	//  1. standard
+	//  2. express
+	Priority string `json:"priority"`
+}
+
+// Courier delivers shipments.
+type Courier struct{}
+
+// Account is a user account with stable property identifiers.
+type Account struct {
+	Login string `json:"login" id:"1"`
+	Email string `json:"email" id:"2"`
+}
+
+// Team groups its members under a lead.
+type Team struct {
+	Members []string          `json:"members"`
+	Tags    []string          `json:"tags"`
+	Labels  map[string]string `json:"labels"`
+	Lead    *Person           `json:"lead"`
+}
+
+// ServerConfig configures an HTTP server.
+type ServerConfig struct {
+	Host  string `json:"host"  default:"localhost"`
+	Port  int    `json:"port"  default:"8080"`
+	Debug bool   `json:"debug"`
+	// Ratio is the fraction of requests which are sampled.
+	Ratio   float64 `json:"ratio"   default:"0.5"`
+	Verbose bool    `json:"verbose" default:"yes"`
+}
+
+// Library is a collection of books stored on an internal shelf.
+type Library struct {
+	Name    string  `json:"name"`
+	Shelf   shelf   `json:"shelf"`
+	Shelves []shelf `json:"shelves"`
+}
+
+// shelf is an internal library shelf.
+type shelf struct {
+	Position int `json:"position"`
+}
+
+// Price is an amount of money in a specific currency.
+type Price struct {
+	Amount   int      `json:"amount"`
+	Currency Currency `json:"currency"`
+	Discount Discount `json:"discount"`
+}
+
+// Currency has no Go doc comment, it is described by its GovydocDescription method.
+
+type Currency string
+
+// GovydocDescription implements govydoc.Documenter.
+func (Currency) GovydocDescription() string {
+	return "Currency is an ISO 4217 currency code."
+}
+
+// Symbol returns the currency's symbol, e.g. "$" for USD.
+func (c Currency) Symbol() string {
+	if c == "USD" {
+		return "$"
+	}
+	return string(c)
+}
+
+// Discount is a percentage reduction of the [Price].
+type Discount struct {
+	Percent int `json:"percent"`
+}
+
+// GovydocDescription implements govydoc.Documenter.
+func (*Discount) GovydocDescription() string {
+	return "Discount is overridden by its Go doc comment."
+}
+
 // ListStruct contains a list of items.
 type ListStruct struct {
 	Items []string `json:"items"`
@@ -66,3 +187,236 @@ type ListStruct struct {
 type MapStruct struct {
 	Data map[string]int `json:"data"`
 }
+
+// Payment is a union of the supported payment methods.
+// Exactly one of its fields must be set.
+type Payment struct {
+	Card     *Card     `json:"card,omitempty"`
+	Transfer *Transfer `json:"transfer,omitempty"`
+}
+
+// Card is a card payment method.
+type Card struct {
+	Number string `json:"number"`
+}
+
+// Transfer is a bank transfer payment method.
+type Transfer struct {
+	Account string `json:"account"`
+}
+
+// Measurement is a value read by a sensor.
+type Measurement struct {
+	// Value is the measured value.
+	Value   json.Number   `json:"value"`
+	History []json.Number `json:"history"`
+	Sensor  Sensor        `json:"sensor"`
+	// Interval is the time between measurements.
+	Interval time.Duration `json:"interval"`
+	// MeasuredAt is the time of the last measurement.
+	MeasuredAt time.Time `json:"measuredAt"`
+}
+
+// Sensor is encoded as its identifier.
+type Sensor struct {
+	ID string `json:"id"`
+}
+
+// Notifier sends notifications.
+type Notifier interface {
+	fmt.Stringer
+	// Notify sends the message to the recipient.
+	Notify(recipient Person, message string) error
+}
+
+// Deployment is labeled with Kubernetes-style keys, which contain dots and slashes.
+type Deployment struct {
+	Name string `json:"name"`
+	// Component is the deployed component.
+	Component Component `json:"app.kubernetes.io/component"`
+}
+
+// Component describes a deployed application component.
+type Component struct {
+	// Version is the component's version.
+	Version  string `json:"app.version"`
+	Replicas int    `json:"replicas"`
+}
+
+// Invoice is a bill for delivered services.
+//
+// Example file: invoice.json
+type Invoice struct {
+	Number string `json:"number"`
+	// Total is the amount due.
+	//
+	// Example file: invoice_total.json
+	Total int `json:"total"`
+}
+
+// Page is a generic page of results.
+type Page[T any] struct {
+	// Items are the results on the page.
+	Items []T `json:"items"`
+	Total int `json:"total"`
+}
+
+// Box holds a single value of any type.
+type Box[T any] struct {
+	// Value is the boxed value.
+	Value T `json:"value"`
+	// Label describes the boxed value.
+	Label string `json:"label"`
+}
+
+// Pair holds a value with its key.
+type Pair[K comparable, V any] struct {
+	// Key identifies the value.
+	Key K `json:"key"`
+	// Entry is optional.
+	Entry *V `json:"entry"`
+}
+
+// LabeledBoxes embeds generic structs.
+type LabeledBoxes struct {
+	Box[Teacher]
+	// Pair is the labeled pair.
+	*Pair[string, Student] `json:"pair"`
+	// Color is the color of the boxes.
+	Color string `json:"color"`
+}
+
+// LoggingConfig is a configuration file encoded as YAML.
+type LoggingConfig struct {
+	// LogLevel is the minimum level of logged messages.
+	LogLevel string `json:"logLevel" yaml:"log_level"`
+	Retries  int    `yaml:"retries,omitempty"`
+}
+
+// Shape is a figure drawn on a canvas.
+type Shape struct {
+	Color Color `json:"color"`
+	Size  Size  `json:"size"`
+}
+
+// Color is the fill color of a [Shape].
+//
+// ENUM(Red=1, Green, Blue)
+type Color int
+
+// Size is the size of a [Shape].
+// ENUM(
+// small,
+// medium
+// large
+// )
+type Size string
+
+// Credentials authenticate a client.
+type Credentials struct {
+	User string `json:"user"`
+	// Password is never encoded.
+	Password string `json:"-"`
+	// Session is the client's current session.
+	Session Session `json:"-"`
+	// token is the cached access token.
+	token string
+}
+
+// Token returns the cached access token.
+func (c Credentials) Token() string {
+	return c.token
+}
+
+// Session identifies a client's session.
+type Session struct {
+	ID string `json:"id"`
+}
+
+// Course embeds an interface declared in another package.
+type Course interface {
+	moremodels.Enrollable
+	// Title returns the course's title.
+	Title() string
+}
+
+// Alert notifies about a problem through a channel.
+type Alert struct {
+	Name string `json:"name"`
+	// Channel is where the alert is sent.
+	Channel Channel `json:"channel"`
+}
+
+// Channel delivers alerts.
+type Channel interface {
+	// Deliver sends the message.
+	Deliver(message string) error
+}
+
+// EmailChannel delivers alerts by email.
+type EmailChannel struct {
+	// Address is the recipient's email address.
+	Address string `json:"address"`
+}
+
+// Deliver implements [Channel].
+func (EmailChannel) Deliver(string) error {
+	return nil
+}
+
+// WebhookChannel delivers alerts to an HTTP endpoint.
+type WebhookChannel struct {
+	URL string `json:"url"`
+}
+
+// Deliver implements [Channel].
+func (*WebhookChannel) Deliver(string) error {
+	return nil
+}
+
+// Enrollment is a student's enrollment with a deprecated field of a deprecated type.
+type Enrollment struct {
+	// Student is the enrolled student.
+	//
+	// Deprecated: Use StudentName instead.
+	Student Student `json:"student"`
+	// StudentName is the name of the enrolled student.
+	StudentName string `json:"studentName"`
+}
+
+// Theme styles the user interface.
+type Theme struct {
+	// Mode is the theme's color mode.
+	Mode     ThemeMode `json:"mode"`
+	Contrast Contrast  `json:"contrast"`
+}
+
+// ThemeMode is the color mode of a [Theme].
+type ThemeMode string
+
+// Supported [ThemeMode] values.
+const (
+	// ThemeModeLight uses dark text on a light background.
+	ThemeModeLight ThemeMode = "light"
+	ThemeModeDark  ThemeMode = "dark" // ThemeModeDark uses light text on a dark background.
+)
+
+// defaultThemeMode is not a separate value of [ThemeMode].
+const defaultThemeMode = ThemeModeLight
+
+// Contrast is the contrast level of a [Theme].
+type Contrast int
+
+// Supported [Contrast] values.
+const (
+	ContrastNormal Contrast = iota
+	ContrastHigh
+)
+
+// ModeOrDefault returns the theme's mode, or the default mode if it is not set.
+func (t Theme) ModeOrDefault() ThemeMode {
+	if t.Mode == "" {
+		return defaultThemeMode
+	}
+	return t.Mode
+}
@@ -4,3 +4,9 @@ package moremodels
 type University struct {
 	SquareMeters int // SquareMeters is the size of the university in square meters.
 }
+
+// Enrollable is implemented by things students can enroll in.
+type Enrollable interface {
+	// Enroll registers the student with the given name.
+	Enroll(student string) error
+}
@@ -0,0 +1,22 @@
+package testmodels
+
+// Pipeline runs steps declared with anonymous struct types.
+type Pipeline struct {
+	// Config configures the pipeline.
+	Config struct {
+		// Host runs the pipeline.
+		Host string `json:"host"`
+		// Retry configures retrying failed steps.
+		Retry *struct {
+			// Attempts limits the retries.
+			Attempts int `json:"attempts"`
+		} `json:"retry,omitempty"`
+	} `json:"config"`
+	// Steps are run in order.
+	Steps []struct {
+		// Name identifies the step.
+		Name string `json:"name"`
+		// Owner is responsible for the step.
+		Owner Student `json:"owner"`
+	} `json:"steps"`
+}
@@ -0,0 +1,7 @@
+package testmodels
+
+// Route is a fixed-length route between addresses.
+type Route struct {
+	// Stops are the addresses the route passes through, in order.
+	Stops [3]Address `json:"stops"`
+}
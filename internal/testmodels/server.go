@@ -0,0 +1,61 @@
+package testmodels
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+)
+
+// Server is reachable at a network address.
+type Server struct {
+	// Address is the IP address of the server.
+	Address net.IP `json:"address"`
+	// Gateway is the address of the server's gateway.
+	Gateway *net.IP `json:"gateway,omitempty"`
+	// Serial identifies the server's hardware.
+	Serial SerialNumber `json:"serial"`
+	// Tags label the server.
+	Tags []SerialNumber `json:"tags,omitempty"`
+	// Location is where the server is mounted.
+	Location Location `json:"location"`
+	// Fingerprint verifies the server's identity.
+	Fingerprint Fingerprint `json:"fingerprint"`
+}
+
+// SerialNumber is encoded as text, like "ACME-42".
+type SerialNumber struct {
+	Vendor string `json:"vendor"`
+	Number int    `json:"number"`
+}
+
+// MarshalText encodes the serial number as "<vendor>-<number>".
+func (s SerialNumber) MarshalText() ([]byte, error) {
+	return fmt.Appendf(nil, "%s-%d", s.Vendor, s.Number), nil
+}
+
+// Location is encoded as a JSON object, as MarshalJSON takes precedence over MarshalText.
+type Location struct {
+	Rack string `json:"rack"`
+	Slot int    `json:"slot"`
+}
+
+// MarshalJSON encodes the location as a JSON object.
+func (l Location) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{"rack": l.Rack, "slot": l.Slot})
+}
+
+// MarshalText encodes the location as "<rack>/<slot>".
+func (l Location) MarshalText() ([]byte, error) {
+	return fmt.Appendf(nil, "%s/%d", l.Rack, l.Slot), nil
+}
+
+// Fingerprint is encoded as text only when it is addressable, as its MarshalText has a pointer receiver.
+type Fingerprint struct {
+	Algorithm string `json:"algorithm"`
+	Digest    string `json:"digest"`
+}
+
+// MarshalText encodes the fingerprint as "<algorithm>:<digest>".
+func (f *Fingerprint) MarshalText() ([]byte, error) {
+	return fmt.Appendf(nil, "%s:%s", f.Algorithm, f.Digest), nil
+}
@@ -1,6 +1,9 @@
 package typeinfo
 
-import "reflect"
+import (
+	"reflect"
+	"strconv"
+)
 
 // TypeInfo stores the Go type information.
 type TypeInfo struct {
@@ -10,8 +13,19 @@ type TypeInfo struct {
 }
 
 // Get returns information about typ with pointer layers removed.
-// Built-in types have an empty package, while slices of named types keep the slice notation in their name.
+// Built-in types have an empty package, while slices and arrays of named types keep the slice or array notation,
+// including the array length, in their name.
 func Get(typ reflect.Type) TypeInfo {
+	return GetWithKinds(typ, nil)
+}
+
+// KindFunc returns a custom kind of typ.
+// It returns false if typ's kind should be derived from its [reflect.Kind].
+type KindFunc func(typ reflect.Type) (string, bool)
+
+// GetWithKinds works like [Get], but uses kindFunc, if not nil, to override the kinds of typ,
+// its elements, keys, and values.
+func GetWithKinds(typ reflect.Type, kindFunc KindFunc) TypeInfo {
 	if typ == nil {
 		return TypeInfo{}
 	}
@@ -19,12 +33,19 @@ func Get(typ reflect.Type) TypeInfo {
 		typ = typ.Elem()
 	}
 	result := TypeInfo{
-		Kind: getKindString(typ),
+		Kind: getKindString(typ, kindFunc),
 	}
 
-	if typ.PkgPath() == "" && typ.Kind() == reflect.Slice {
-		result.Name = "[]"
-		typ = typ.Elem()
+	if typ.PkgPath() == "" {
+		switch typ.Kind() {
+		case reflect.Slice:
+			result.Name = "[]"
+			typ = typ.Elem()
+		case reflect.Array:
+			result.Name = "[" + strconv.Itoa(typ.Len()) + "]"
+			typ = typ.Elem()
+		default:
+		}
 	}
 	switch {
 	case typ.PkgPath() == "":
@@ -36,12 +57,19 @@ func Get(typ reflect.Type) TypeInfo {
 	return result
 }
 
-func getKindString(typ reflect.Type) string {
+func getKindString(typ reflect.Type, kindFunc KindFunc) string {
+	if kindFunc != nil {
+		if kind, ok := kindFunc(typ); ok {
+			return kind
+		}
+	}
 	switch typ.Kind() {
 	case reflect.Map:
-		return "map[" + getKindString(typ.Key()) + "]" + getKindString(typ.Elem())
+		return "map[" + getKindString(typ.Key(), kindFunc) + "]" + getKindString(typ.Elem(), kindFunc)
 	case reflect.Slice:
-		return "[]" + getKindString(typ.Elem())
+		return "[]" + getKindString(typ.Elem(), kindFunc)
+	case reflect.Array:
+		return "[" + strconv.Itoa(typ.Len()) + "]" + getKindString(typ.Elem(), kindFunc)
 	default:
 		return typ.Kind().String()
 	}
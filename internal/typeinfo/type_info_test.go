@@ -37,6 +37,14 @@ func TestGet(t *testing.T) {
 			typ:      reflect.TypeFor[[]customString](),
 			expected: TypeInfo{Name: "[]customString", Package: packageName, Kind: "[]string"},
 		},
+		"array of int": {
+			typ:      reflect.TypeFor[[3]int](),
+			expected: TypeInfo{Name: "[3]int", Kind: "[3]int"},
+		},
+		"array of custom string": {
+			typ:      reflect.TypeFor[[3]customString](),
+			expected: TypeInfo{Name: "[3]customString", Package: packageName, Kind: "[3]string"},
+		},
 		"map of string to int": {
 			typ:      reflect.TypeFor[map[string]int](),
 			expected: TypeInfo{Name: "map[string]int", Kind: "map[string]int"},
@@ -73,6 +81,10 @@ func TestGet(t *testing.T) {
 			typ:      reflect.TypeFor[customStringSlice](),
 			expected: TypeInfo{Name: "customStringSlice", Package: packageName, Kind: "[]string"},
 		},
+		"custom array": {
+			typ:      reflect.TypeFor[customArray](),
+			expected: TypeInfo{Name: "customArray", Package: packageName, Kind: "[2][]string"},
+		},
 	}
 
 	for name, test := range tests {
@@ -83,6 +95,25 @@ func TestGet(t *testing.T) {
 	}
 }
 
+func TestGetWithKinds(t *testing.T) {
+	kindFunc := func(typ reflect.Type) (string, bool) {
+		if typ == reflect.TypeFor[customString]() {
+			return "text", true
+		}
+		return "", false
+	}
+
+	assert.Equal(t,
+		TypeInfo{Name: "customString", Package: packageName, Kind: "text"},
+		GetWithKinds(reflect.TypeFor[*customString](), kindFunc))
+	assert.Equal(t,
+		TypeInfo{Name: "customNestedMap", Package: packageName, Kind: "map[text][]map[string]int"},
+		GetWithKinds(reflect.TypeFor[customNestedMap](), kindFunc))
+	assert.Equal(t,
+		TypeInfo{Name: "int", Kind: "int"},
+		GetWithKinds(reflect.TypeFor[int](), kindFunc))
+}
+
 const packageName = "github.com/nieomylnieja/govydoc/internal/typeinfo"
 
 type customString string
@@ -95,4 +126,6 @@ type customSlice []customMap
 
 type customStringSlice []string
 
+type customArray [2]customStringSlice
+
 type customNestedMap map[customString]customSlice
@@ -0,0 +1,122 @@
+package govydoc
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/nieomylnieja/govydoc/internal/godoc"
+)
+
+// WithCacheDir returns an option that caches the parsed Go documentation of the documented type in dir,
+// so that subsequent runs read it from there instead of loading the module's packages.
+// Cache entries are invalidated when go.mod, go.sum, the Go version,
+// or any of the non-test Go source files of the documented modules change, see [WithModuleRoots].
+// Changes to dependencies replaced with local directories are not detected.
+// The source files are hashed once and reused by the subsequent calls, see [documentationCaches],
+// so changes made while the program runs are not detected either.
+// The cache is disabled by default.
+func WithCacheDir(dir string) GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.cacheDir = dir
+		return options
+	}
+}
+
+// WithNoCache returns an option that disables the cache enabled with [WithCacheDir],
+// e.g. for a single validator documented by a [Generator] which caches the others.
+func WithNoCache() GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.noCache = true
+		return options
+	}
+}
+
+// documentationCaches holds the caches used with [WithCacheDir] by their directories and module roots.
+// Opening a cache computes the digest of the modules' source files, which is why the caches are opened once
+// and reused, by every [Generator] for the documentation it generates, and by [sharedCaches] for [Generate].
+// Like the loaded packages, the digest is not updated when the source files change afterwards.
+type documentationCaches struct {
+	mu     sync.Mutex
+	caches map[documentationCacheKey]func() (*godoc.Cache, error)
+}
+
+type documentationCacheKey struct {
+	dir string
+	// moduleRoots are the module roots joined with a null character.
+	moduleRoots string
+}
+
+// sharedCaches are the caches shared by [Generate] calls, like [sharedGenerators].
+var sharedCaches documentationCaches
+
+// get returns the cache stored in dir for the modules with the roots, opening it on first use.
+func (c *documentationCaches) get(dir string, moduleRoots []string) (*godoc.Cache, error) {
+	key := documentationCacheKey{dir: dir, moduleRoots: strings.Join(moduleRoots, "\x00")}
+	c.mu.Lock()
+	open, ok := c.caches[key]
+	if !ok {
+		if c.caches == nil {
+			c.caches = make(map[documentationCacheKey]func() (*godoc.Cache, error), 1)
+		}
+		open = sync.OnceValues(func() (*godoc.Cache, error) { return godoc.NewCache(dir, moduleRoots...) })
+		c.caches[key] = open
+	}
+	c.mu.Unlock()
+	return open()
+}
+
+// parseGoDoc returns the Go documentation of typ, reading it from the cache if [WithCacheDir] is used.
+// The parser is only loaded if the documentation is not cached.
+func parseGoDoc(
+	ctx context.Context,
+	typ reflect.Type,
+	loadParser func() (*godoc.Parser, error),
+	caches *documentationCaches,
+	options generateOptions,
+) (godoc.Docs, []string, error) {
+	var cache *godoc.Cache
+	if options.cacheDir != "" && !options.noCache {
+		var err error
+		cache, err = caches.get(options.cacheDir, options.moduleRoots)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open documentation cache: %w", err)
+		}
+		start := options.startProgress()
+		if docs, warnings, ok := cache.Load(typ, options.parseOptions()); ok {
+			options.reportProgress(ProgressTypeParsed, typ, len(docs), start)
+			return docs, warnings, nil
+		}
+	}
+
+	goDocParser, err := loadParser()
+	if err != nil {
+		return nil, nil, err
+	}
+	start := options.startProgress()
+	docs, warnings, err := goDocParser.ParseContext(ctx, typ, options.parseOptions())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse documentation for %s: %w", typ, err)
+	}
+	options.reportProgress(ProgressTypeParsed, typ, len(docs), start)
+	if cache != nil {
+		if err = cache.Store(typ, options.parseOptions(), docs, warnings); err != nil {
+			return nil, nil, fmt.Errorf("failed to cache documentation for %s: %w", typ, err)
+		}
+	}
+	return docs, warnings, nil
+}
+
+// parseOptions returns the [godoc.ParseOptions] matching how the properties are mapped from the documented type.
+func (o generateOptions) parseOptions() godoc.ParseOptions {
+	return godoc.ParseOptions{
+		TagName:          o.tagName,
+		NestEmbedded:     o.embeddedMode == EmbeddedModeNest,
+		IncludeHidden:    o.includeHidden || o.includeUnexported,
+		TrailingComments: o.trailingComments,
+		Examples:         o.goExamples,
+		DocLinkBaseURL:   o.docLinkBaseURL,
+	}
+}
@@ -0,0 +1,80 @@
+package govydoc
+
+import (
+	"os"
+	"testing"
+
+	"github.com/nobl9/govy/pkg/govy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nieomylnieja/govydoc/internal/testmodels"
+)
+
+func TestWithCacheDir(t *testing.T) {
+	validator := govy.New[testmodels.Teacher]()
+	expected, err := Generate(validator, WithDocBlocks())
+	require.NoError(t, err)
+
+	cacheDir := t.TempDir()
+	generateCached := func(opts ...GenerateOption) (ObjectDoc, []ProgressStage) {
+		t.Helper()
+		var stages []ProgressStage
+		opts = append(
+			[]GenerateOption{WithDocBlocks(), WithCacheDir(cacheDir)},
+			append(opts, WithProgress(func(event ProgressEvent) { stages = append(stages, event.Stage) }))...,
+		)
+		doc, err := Generate(validator, opts...)
+		require.NoError(t, err)
+		return doc, stages
+	}
+
+	doc, stages := generateCached()
+	assert.Equal(t, expected, doc)
+	assert.Contains(t, stages, ProgressPackagesLoaded)
+	entries, err := os.ReadDir(cacheDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	doc, stages = generateCached()
+	assert.Equal(t, expected, doc)
+	assert.NotContains(t, stages, ProgressPackagesLoaded)
+	assert.Contains(t, stages, ProgressTypeParsed)
+
+	doc, stages = generateCached(WithNoCache())
+	assert.Equal(t, expected, doc)
+	assert.Contains(t, stages, ProgressPackagesLoaded)
+}
+
+func TestWithNoCache(t *testing.T) {
+	cacheDir := t.TempDir()
+	generator, err := NewGenerator(WithCacheDir(cacheDir))
+	require.NoError(t, err)
+
+	_, err = generator.Generate(NewAnyValidator(govy.New[testmodels.Address](), WithNoCache()))
+	require.NoError(t, err)
+	entries, err := os.ReadDir(cacheDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	_, err = generator.Generate(NewAnyValidator(govy.New[testmodels.Address]()))
+	require.NoError(t, err)
+	entries, err = os.ReadDir(cacheDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func Test_documentationCaches(t *testing.T) {
+	var caches documentationCaches
+	dir := t.TempDir()
+
+	cache, err := caches.get(dir, nil)
+	require.NoError(t, err)
+	reused, err := caches.get(dir, nil)
+	require.NoError(t, err)
+	assert.Same(t, cache, reused)
+
+	other, err := caches.get(t.TempDir(), nil)
+	require.NoError(t, err)
+	assert.NotSame(t, cache, other)
+}
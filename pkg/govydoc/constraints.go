@@ -0,0 +1,189 @@
+package govydoc
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nobl9/govy/pkg/govy"
+	"github.com/nobl9/govy/pkg/rules"
+)
+
+// Constraints aggregates the constraints of a property recognized from its validation rules.
+// Only rules without conditions are taken into account.
+type Constraints struct {
+	// MinLen is the minimum length of a string, slice, or map.
+	MinLen *int `json:"minLength,omitempty"`
+	// MaxLen is the maximum length of a string, slice, or map.
+	MaxLen *int `json:"maxLength,omitempty"`
+	// Pattern is the regular expression the property must match.
+	Pattern string `json:"pattern,omitempty"`
+	// Enum lists the values allowed by the validation rules, that is, [govy.PropertyPlan.Values].
+	// Unlike [PropertyDoc.AllowedValues], it does not fall back to the values declared with the property's type.
+	Enum []string `json:"enum,omitempty"`
+	// Min is the lower bound of a numeric property.
+	Min *float64 `json:"minimum,omitempty"`
+	// Max is the upper bound of a numeric property.
+	Max *float64 `json:"maximum,omitempty"`
+	// ExclusiveMin is true if the property must be greater than Min.
+	ExclusiveMin bool `json:"exclusiveMinimum,omitempty"`
+	// ExclusiveMax is true if the property must be less than Max.
+	ExclusiveMax bool `json:"exclusiveMaximum,omitempty"`
+	// Conflicts describes recognized constraints which cannot be aggregated,
+	// e.g. a minimum length greater than the maximum length.
+	Conflicts []string `json:"conflicts,omitempty"`
+}
+
+const matchRegexpDescriptionPrefix = "string must match regular expression: "
+
+var (
+	lengthBetweenRegex = regexp.MustCompile(`^length must be between (\d+) and (\d+)$`)
+	minLengthRegex     = regexp.MustCompile(`^length must be greater than or equal to (\d+)$`)
+	maxLengthRegex     = regexp.MustCompile(`^length must be less than or equal to (\d+)$`)
+	comparisonRegex    = regexp.MustCompile(`^must be (?:greater|less) than (?:or equal to )?'(.*)'$`)
+)
+
+// aggregateConstraints sets [PropertyDoc.Constraints] based on the recognized rules of the property.
+// Rules' plans only expose their parameters through descriptions, which is why they are parsed from them.
+func aggregateConstraints(doc PropertyDoc) PropertyDoc {
+	c := Constraints{Enum: slices.Clone(doc.Values)}
+	for _, rule := range doc.Rules {
+		if len(rule.Conditions) > 0 {
+			continue
+		}
+		c.addRule(rule)
+	}
+	c.findConflicts()
+	if !c.isEmpty() {
+		doc.Constraints = &c
+	}
+	return doc
+}
+
+func (c *Constraints) addRule(rule govy.RulePlan) {
+	switch rule.ErrorCode {
+	case rules.ErrorCodeStringLength, rules.ErrorCodeSliceLength, rules.ErrorCodeMapLength:
+		if m := lengthBetweenRegex.FindStringSubmatch(rule.Description); m != nil {
+			c.addMinLen(m[1])
+			c.addMaxLen(m[2])
+		}
+	case rules.ErrorCodeStringMinLength, rules.ErrorCodeSliceMinLength, rules.ErrorCodeMapMinLength:
+		if m := minLengthRegex.FindStringSubmatch(rule.Description); m != nil {
+			c.addMinLen(m[1])
+		}
+	case rules.ErrorCodeStringMaxLength, rules.ErrorCodeSliceMaxLength, rules.ErrorCodeMapMaxLength:
+		if m := maxLengthRegex.FindStringSubmatch(rule.Description); m != nil {
+			c.addMaxLen(m[1])
+		}
+	case rules.ErrorCodeStringMatchRegexp:
+		pattern, found := strings.CutPrefix(rule.Description, matchRegexpDescriptionPrefix)
+		if !found {
+			return
+		}
+		pattern = strings.TrimSuffix(strings.TrimPrefix(pattern, "'"), "'")
+		switch c.Pattern {
+		case "", pattern:
+			c.Pattern = pattern
+		default:
+			c.Conflicts = append(c.Conflicts,
+				fmt.Sprintf("pattern '%s' cannot be combined with pattern '%s'", pattern, c.Pattern))
+		}
+	case rules.ErrorCodeGreaterThan, rules.ErrorCodeGreaterThanOrEqualTo:
+		if value, ok := parseComparisonValue(rule.Description); ok {
+			c.addMin(value, rule.ErrorCode == rules.ErrorCodeGreaterThan)
+		}
+	case rules.ErrorCodeLessThan, rules.ErrorCodeLessThanOrEqualTo:
+		if value, ok := parseComparisonValue(rule.Description); ok {
+			c.addMax(value, rule.ErrorCode == rules.ErrorCodeLessThan)
+		}
+	}
+}
+
+// addMinLen keeps the stricter of the minimum lengths.
+func (c *Constraints) addMinLen(s string) {
+	length, err := strconv.Atoi(s)
+	if err != nil {
+		return
+	}
+	if c.MinLen == nil || length > *c.MinLen {
+		c.MinLen = &length
+	}
+}
+
+// addMaxLen keeps the stricter of the maximum lengths.
+func (c *Constraints) addMaxLen(s string) {
+	length, err := strconv.Atoi(s)
+	if err != nil {
+		return
+	}
+	if c.MaxLen == nil || length < *c.MaxLen {
+		c.MaxLen = &length
+	}
+}
+
+// addMin keeps the stricter of the lower bounds.
+func (c *Constraints) addMin(value float64, exclusive bool) {
+	if c.Min == nil || value > *c.Min || (value == *c.Min && exclusive) {
+		c.Min = &value
+		c.ExclusiveMin = exclusive
+	}
+}
+
+// addMax keeps the stricter of the upper bounds.
+func (c *Constraints) addMax(value float64, exclusive bool) {
+	if c.Max == nil || value < *c.Max || (value == *c.Max && exclusive) {
+		c.Max = &value
+		c.ExclusiveMax = exclusive
+	}
+}
+
+func (c *Constraints) findConflicts() {
+	if c.MinLen != nil && c.MaxLen != nil && *c.MinLen > *c.MaxLen {
+		c.Conflicts = append(c.Conflicts,
+			fmt.Sprintf("minimum length %d is greater than maximum length %d", *c.MinLen, *c.MaxLen))
+	}
+	if c.Min != nil && c.Max != nil &&
+		(*c.Min > *c.Max || (*c.Min == *c.Max && (c.ExclusiveMin || c.ExclusiveMax))) {
+		c.Conflicts = append(c.Conflicts,
+			fmt.Sprintf("range between minimum %v and maximum %v is empty", *c.Min, *c.Max))
+	}
+}
+
+func (c *Constraints) clone() Constraints {
+	clone := *c
+	if c.MinLen != nil {
+		minLen := *c.MinLen
+		clone.MinLen = &minLen
+	}
+	if c.MaxLen != nil {
+		maxLen := *c.MaxLen
+		clone.MaxLen = &maxLen
+	}
+	if c.Min != nil {
+		minimum := *c.Min
+		clone.Min = &minimum
+	}
+	if c.Max != nil {
+		maximum := *c.Max
+		clone.Max = &maximum
+	}
+	clone.Enum = slices.Clone(c.Enum)
+	clone.Conflicts = slices.Clone(c.Conflicts)
+	return clone
+}
+
+func (c *Constraints) isEmpty() bool {
+	return c.MinLen == nil && c.MaxLen == nil && c.Pattern == "" && len(c.Enum) == 0 &&
+		c.Min == nil && c.Max == nil && len(c.Conflicts) == 0
+}
+
+func parseComparisonValue(description string) (float64, bool) {
+	m := comparisonRegex.FindStringSubmatch(description)
+	if m == nil {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(m[1], 64)
+	return value, err == nil
+}
@@ -0,0 +1,130 @@
+package govydoc
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/nobl9/govy/pkg/govy"
+	"github.com/nobl9/govy/pkg/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nieomylnieja/govydoc/internal/testmodels"
+)
+
+func TestGenerate_Constraints(t *testing.T) {
+	validator := govy.New(
+		govy.For(func(t testmodels.Teacher) string { return t.Hobby }).
+			WithName("hobby").
+			Rules(
+				rules.StringLength(3, 20),
+				rules.StringMinLength(5),
+				rules.StringMatchRegexp(regexp.MustCompile(`^[a-z]+$`)),
+			),
+		govy.For(func(t testmodels.Teacher) int { return t.Age }).
+			WithName("age").
+			Rules(rules.GT(18), rules.LTE(65)),
+	).WithName("Teacher")
+
+	doc, err := Generate(validator)
+	require.NoError(t, err)
+
+	assert.Equal(t, &Constraints{
+		MinLen:  ptr(5),
+		MaxLen:  ptr(20),
+		Pattern: "^[a-z]+$",
+	}, findProperty(t, doc, "$.hobby").Constraints)
+	assert.Equal(t, &Constraints{
+		Min:          ptr(18.0),
+		Max:          ptr(65.0),
+		ExclusiveMin: true,
+	}, findProperty(t, doc, "$.age").Constraints)
+	assert.Nil(t, findProperty(t, doc, "$.students").Constraints)
+}
+
+func Test_aggregateConstraints(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		rules    []govy.RulePlan
+		values   []string
+		expected *Constraints
+	}{
+		"no recognized rules": {
+			rules: []govy.RulePlan{{Description: "string must not be empty", ErrorCode: rules.ErrorCodeStringNotEmpty}},
+		},
+		"enum": {
+			rules:    []govy.RulePlan{{Description: "must be one of: a, b", ErrorCode: rules.ErrorCodeOneOf}},
+			values:   []string{"a", "b"},
+			expected: &Constraints{Enum: []string{"a", "b"}},
+		},
+		"slice length": {
+			rules: []govy.RulePlan{
+				{Description: "length must be less than or equal to 3", ErrorCode: rules.ErrorCodeSliceMaxLength},
+			},
+			expected: &Constraints{MaxLen: ptr(3)},
+		},
+		"stricter bounds": {
+			rules: []govy.RulePlan{
+				{Description: "must be greater than or equal to '1'", ErrorCode: rules.ErrorCodeGreaterThanOrEqualTo},
+				{Description: "must be greater than '1'", ErrorCode: rules.ErrorCodeGreaterThan},
+				{Description: "must be less than '10'", ErrorCode: rules.ErrorCodeLessThan},
+				{Description: "must be less than or equal to '5'", ErrorCode: rules.ErrorCodeLessThanOrEqualTo},
+			},
+			expected: &Constraints{Min: ptr(1.0), ExclusiveMin: true, Max: ptr(5.0)},
+		},
+		"non-numeric bound": {
+			rules: []govy.RulePlan{{Description: "must be greater than 'a'", ErrorCode: rules.ErrorCodeGreaterThan}},
+		},
+		"conditional rule": {
+			rules: []govy.RulePlan{{
+				Description: "length must be between 1 and 2",
+				ErrorCode:   rules.ErrorCodeStringLength,
+				Conditions:  []string{"when enabled"},
+			}},
+		},
+		"conflicting lengths": {
+			rules: []govy.RulePlan{
+				{Description: "length must be greater than or equal to 10", ErrorCode: rules.ErrorCodeStringMinLength},
+				{Description: "length must be less than or equal to 5", ErrorCode: rules.ErrorCodeStringMaxLength},
+			},
+			expected: &Constraints{
+				MinLen:    ptr(10),
+				MaxLen:    ptr(5),
+				Conflicts: []string{"minimum length 10 is greater than maximum length 5"},
+			},
+		},
+		"conflicting bounds": {
+			rules: []govy.RulePlan{
+				{Description: "must be greater than '5'", ErrorCode: rules.ErrorCodeGreaterThan},
+				{Description: "must be less than or equal to '5'", ErrorCode: rules.ErrorCodeLessThanOrEqualTo},
+			},
+			expected: &Constraints{
+				Min:          ptr(5.0),
+				Max:          ptr(5.0),
+				ExclusiveMin: true,
+				Conflicts:    []string{"range between minimum 5 and maximum 5 is empty"},
+			},
+		},
+		"conflicting patterns": {
+			rules: []govy.RulePlan{
+				{Description: "string must match regular expression: '^a'", ErrorCode: rules.ErrorCodeStringMatchRegexp},
+				{Description: "string must match regular expression: 'b$'", ErrorCode: rules.ErrorCodeStringMatchRegexp},
+			},
+			expected: &Constraints{
+				Pattern:   "^a",
+				Conflicts: []string{"pattern 'b$' cannot be combined with pattern '^a'"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			doc := PropertyDoc{PropertyPlan: govy.PropertyPlan{Rules: test.rules, Values: test.values}}
+			assert.Equal(t, test.expected, aggregateConstraints(doc).Constraints)
+		})
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
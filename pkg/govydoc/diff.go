@@ -0,0 +1,172 @@
+package govydoc
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/nobl9/govy/pkg/govy"
+)
+
+// ChangeKind describes how a property changed between two versions of an [ObjectDoc], see [Diff].
+type ChangeKind string
+
+// Supported [ChangeKind] values.
+const (
+	// ChangeKindPropertyAdded is the kind of properties documented only in the new version.
+	ChangeKindPropertyAdded ChangeKind = "propertyAdded"
+	// ChangeKindPropertyRemoved is the kind of properties documented only in the old version.
+	ChangeKindPropertyRemoved ChangeKind = "propertyRemoved"
+	// ChangeKindTypeChanged is the kind of properties whose [govy.PropertyPlan.TypeInfo] changed.
+	ChangeKindTypeChanged ChangeKind = "typeChanged"
+	// ChangeKindRulesChanged is the kind of properties whose rules changed,
+	// that is, the names, descriptions, or conditions of their [PropertyDoc.RuleDocs].
+	ChangeKindRulesChanged ChangeKind = "rulesChanged"
+	// ChangeKindDeprecated is the kind of properties which became deprecated,
+	// that is, whose [PropertyDoc.DeprecatedDoc] was set only in the new version.
+	ChangeKindDeprecated ChangeKind = "deprecated"
+	// ChangeKindTypeDeprecated is the kind of properties whose type became deprecated,
+	// that is, whose [PropertyDoc.TypeDeprecatedDoc] was set only in the new version.
+	ChangeKindTypeDeprecated ChangeKind = "typeDeprecated"
+)
+
+// Change is a difference between two versions of a property, see [Diff].
+type Change struct {
+	Kind ChangeKind `json:"kind"`
+	// Path is the JSON path of the changed property.
+	Path string `json:"path"`
+	// Old and New describe the changed value: the type, e.g. "time.Duration", for [ChangeKindTypeChanged],
+	// the rules, one per line, for [ChangeKindRulesChanged], e.g. "string_length: length must be between 1 and 5",
+	// and the deprecation notice for [ChangeKindDeprecated] and [ChangeKindTypeDeprecated], which have no Old value.
+	// They are empty for added and removed properties.
+	Old string `json:"old,omitempty"`
+	New string `json:"new,omitempty"`
+}
+
+// Diff compares the properties of two versions of an [ObjectDoc], matching them by their paths.
+// It reports added and removed properties, changed types and rules, and newly deprecated properties and types,
+// which allows detecting schema changes, e.g. in CI.
+// The changes are ordered by their paths, as with [WithSortedPaths], and, for every path, in the order of
+// the [ChangeKind] constants.
+// An error is returned if a path is documented more than once in either version.
+func Diff(oldDoc, newDoc ObjectDoc) ([]Change, error) {
+	oldProperties, err := propertiesByPath(oldDoc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid old documentation: %w", err)
+	}
+	newProperties, err := propertiesByPath(newDoc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid new documentation: %w", err)
+	}
+
+	var changes []Change
+	for path, oldProperty := range oldProperties {
+		newProperty, found := newProperties[path]
+		if !found {
+			changes = append(changes, Change{Kind: ChangeKindPropertyRemoved, Path: path})
+			continue
+		}
+		changes = append(changes, diffProperty(path, oldProperty, newProperty)...)
+	}
+	for path := range newProperties {
+		if _, found := oldProperties[path]; !found {
+			changes = append(changes, Change{Kind: ChangeKindPropertyAdded, Path: path})
+		}
+	}
+	slices.SortFunc(changes, func(a, b Change) int {
+		return cmp.Or(
+			comparePaths(a.Path, b.Path),
+			cmp.Compare(changeKindOrder(a.Kind), changeKindOrder(b.Kind)),
+		)
+	})
+	return changes, nil
+}
+
+func propertiesByPath(doc ObjectDoc) (map[string]PropertyDoc, error) {
+	properties := make(map[string]PropertyDoc, len(doc.Properties))
+	for _, property := range doc.Properties {
+		path := property.Path.String()
+		if _, found := properties[path]; found {
+			return nil, fmt.Errorf("property %s is documented more than once", path)
+		}
+		properties[path] = property
+	}
+	return properties, nil
+}
+
+func diffProperty(path string, oldProperty, newProperty PropertyDoc) []Change {
+	var changes []Change
+	if oldProperty.TypeInfo != newProperty.TypeInfo {
+		changes = append(changes, Change{
+			Kind: ChangeKindTypeChanged,
+			Path: path,
+			Old:  typeInfoString(oldProperty.TypeInfo),
+			New:  typeInfoString(newProperty.TypeInfo),
+		})
+	}
+	if oldRules, newRules := diffRuleDocs(oldProperty), diffRuleDocs(newProperty); !slices.Equal(oldRules, newRules) {
+		changes = append(changes, Change{
+			Kind: ChangeKindRulesChanged,
+			Path: path,
+			Old:  strings.Join(oldRules, "\n"),
+			New:  strings.Join(newRules, "\n"),
+		})
+	}
+	if !isDeprecated(oldProperty) && isDeprecated(newProperty) {
+		changes = append(changes, Change{Kind: ChangeKindDeprecated, Path: path, New: newProperty.DeprecatedDoc})
+	}
+	if oldProperty.TypeDeprecatedDoc == "" && newProperty.TypeDeprecatedDoc != "" {
+		changes = append(changes, Change{Kind: ChangeKindTypeDeprecated, Path: path, New: newProperty.TypeDeprecatedDoc})
+	}
+	return changes
+}
+
+// diffRuleDocs returns the compared parts of the property's [RuleDoc], that is, the name,
+// the description, and the conditions of every rule, e.g. "string_length: length must be between 1 and 5".
+// The rule docs are created from [govy.PropertyPlan.Rules] if [PropertyDoc.RuleDocs] are not set,
+// e.g. in documentation created by hand.
+func diffRuleDocs(property PropertyDoc) []string {
+	ruleDocs := property.RuleDocs
+	if ruleDocs == nil {
+		for _, rule := range property.Rules {
+			ruleDocs = append(ruleDocs, newRuleDoc(rule))
+		}
+	}
+	list := make([]string, 0, len(ruleDocs))
+	for _, ruleDoc := range ruleDocs {
+		description := ruleDoc.Description
+		if ruleDoc.Name != "" {
+			description = ruleDoc.Name + ": " + description
+		}
+		if len(ruleDoc.Conditions) > 0 {
+			description += " (" + strings.Join(ruleDoc.Conditions, ", ") + ")"
+		}
+		list = append(list, description)
+	}
+	return list
+}
+
+// typeInfoString returns the type qualified with its package, e.g. "time.Duration",
+// or its kind if the type is unnamed, e.g. "map[string]int".
+func typeInfoString(info govy.TypeInfo) string {
+	switch {
+	case info.Name == "":
+		return info.Kind
+	case info.Package == "":
+		return info.Name
+	default:
+		return info.Package + "." + info.Name
+	}
+}
+
+func changeKindOrder(kind ChangeKind) int {
+	return slices.Index([]ChangeKind{
+		ChangeKindPropertyAdded,
+		ChangeKindPropertyRemoved,
+		ChangeKindTypeChanged,
+		ChangeKindRulesChanged,
+		ChangeKindDeprecated,
+		ChangeKindTypeDeprecated,
+	}, kind)
+}
@@ -0,0 +1,118 @@
+package govydoc
+
+import (
+	"testing"
+
+	"github.com/nobl9/govy/pkg/govy"
+	"github.com/nobl9/govy/pkg/jsonpath"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	property := func(path string, typeInfo govy.TypeInfo, rules ...govy.RulePlan) PropertyDoc {
+		return PropertyDoc{PropertyPlan: govy.PropertyPlan{
+			Path:     jsonpath.Parse(path),
+			TypeInfo: typeInfo,
+			Rules:    rules,
+		}}
+	}
+	stringType := govy.TypeInfo{Name: "string", Kind: "string"}
+	durationType := govy.TypeInfo{Name: "Duration", Kind: "int64", Package: "time"}
+	required := govy.RulePlan{Description: "property is required"}
+	positive := govy.RulePlan{Description: "must be greater than '0'", Conditions: []string{"timeout is set"}}
+
+	deprecated := property("$.alias", stringType)
+	deprecated.DeprecatedDoc = "Use name instead."
+	oldDoc := ObjectDoc{Properties: []PropertyDoc{
+		property("$", govy.TypeInfo{Name: "Config", Kind: "struct"}),
+		property("$.name", stringType, required),
+		property("$.alias", stringType),
+		property("$.timeout", stringType),
+		property("$.legacy", stringType),
+	}}
+	newDoc := ObjectDoc{Properties: []PropertyDoc{
+		property("$", govy.TypeInfo{Name: "Config", Kind: "struct"}),
+		property("$.name", stringType),
+		deprecated,
+		property("$.timeout", durationType, positive),
+		property("$.labels", govy.TypeInfo{Kind: "map[string]string"}),
+	}}
+
+	changes, err := Diff(oldDoc, newDoc)
+	require.NoError(t, err)
+	assert.Equal(t, []Change{
+		{Kind: ChangeKindDeprecated, Path: "$.alias", New: "Use name instead."},
+		{Kind: ChangeKindPropertyAdded, Path: "$.labels"},
+		{Kind: ChangeKindPropertyRemoved, Path: "$.legacy"},
+		{Kind: ChangeKindRulesChanged, Path: "$.name", Old: "property is required"},
+		{Kind: ChangeKindTypeChanged, Path: "$.timeout", Old: "string", New: "time.Duration"},
+		{Kind: ChangeKindRulesChanged, Path: "$.timeout", New: "must be greater than '0' (timeout is set)"},
+	}, changes)
+
+	t.Run("rule docs", func(t *testing.T) {
+		withRuleDocs := func(ruleDocs ...RuleDoc) ObjectDoc {
+			return ObjectDoc{Properties: []PropertyDoc{{
+				PropertyPlan: govy.PropertyPlan{Path: jsonpath.Parse("$.name"), TypeInfo: stringType},
+				RuleDocs:     ruleDocs,
+			}}}
+		}
+		minLength := RuleDoc{Name: "string_min_length", Description: "length must be greater than or equal to 1"}
+		renamed := minLength
+		renamed.Name = "custom_min_length"
+		conditional := minLength
+		conditional.Conditions = []string{"name is set"}
+
+		changes, err := Diff(withRuleDocs(minLength), withRuleDocs(renamed))
+		require.NoError(t, err)
+		assert.Equal(t, []Change{{
+			Kind: ChangeKindRulesChanged,
+			Path: "$.name",
+			Old:  "string_min_length: length must be greater than or equal to 1",
+			New:  "custom_min_length: length must be greater than or equal to 1",
+		}}, changes)
+
+		changes, err = Diff(withRuleDocs(minLength), withRuleDocs(conditional))
+		require.NoError(t, err)
+		assert.Equal(t, []Change{{
+			Kind: ChangeKindRulesChanged,
+			Path: "$.name",
+			Old:  "string_min_length: length must be greater than or equal to 1",
+			New:  "string_min_length: length must be greater than or equal to 1 (name is set)",
+		}}, changes)
+	})
+
+	t.Run("type deprecated", func(t *testing.T) {
+		typeDeprecated := property("$.timeout", stringType)
+		typeDeprecated.TypeDeprecatedDoc = "Use Duration instead."
+
+		changes, err := Diff(
+			ObjectDoc{Properties: []PropertyDoc{property("$.timeout", stringType)}},
+			ObjectDoc{Properties: []PropertyDoc{typeDeprecated}},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, []Change{
+			{Kind: ChangeKindTypeDeprecated, Path: "$.timeout", New: "Use Duration instead."},
+		}, changes)
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		changes, err := Diff(newDoc, newDoc.Clone())
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("removed deprecation", func(t *testing.T) {
+		changes, err := Diff(newDoc, oldDoc)
+		require.NoError(t, err)
+		for _, change := range changes {
+			assert.NotEqual(t, ChangeKindDeprecated, change.Kind)
+		}
+	})
+
+	t.Run("duplicated path", func(t *testing.T) {
+		duplicated := ObjectDoc{Properties: []PropertyDoc{property("$.name", stringType), property("$.name", stringType)}}
+		_, err := Diff(oldDoc, duplicated)
+		require.EqualError(t, err, "invalid new documentation: property $.name is documented more than once")
+	})
+}
@@ -62,6 +62,7 @@
 //   - Properties: Array of PropertyDoc with path, type, validation rules, and documentation
 //   - Examples: Optional usage examples
 //   - Doc: Type-level documentation from godoc comments
+//   - PackageDoc: The package comment of the documented type's package
 //
 // Each PropertyDoc includes:
 //
@@ -70,6 +71,17 @@
 //   - Rules: Validation rules from govy
 //   - TypeDoc: Documentation for the property's type
 //   - FieldDoc: Inline documentation from the struct field
-//   - DeprecatedDoc: Contents of "Deprecated:" comments
+//   - DeprecatedDoc: Contents of the struct field's "Deprecated:" comment
+//   - TypeDeprecatedDoc: Contents of the type's "Deprecated:" comment
 //   - ChildrenPaths: Paths of immediate nested properties
+//
+// # Templates
+//
+// RenderTemplate executes a user-supplied html/template with ObjectDoc as its data.
+// Register FuncMap on the template to use the childrenOf, isDeprecated, and ruleList helpers:
+//
+//	tmpl := template.Must(template.New("doc").Funcs(govydoc.FuncMap()).Parse(
+//	    `{{ range .Properties }}{{ .Path }}: {{ range ruleList . }}{{ . }} {{ end }}{{ end }}`,
+//	))
+//	err := govydoc.RenderTemplate(doc, tmpl, os.Stdout)
 package govydoc
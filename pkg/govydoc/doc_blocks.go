@@ -0,0 +1,94 @@
+package govydoc
+
+import (
+	"go/doc/comment"
+	"slices"
+	"strings"
+)
+
+// DocBlockKind is the kind of [DocBlock].
+type DocBlockKind string
+
+// Supported [DocBlockKind] values.
+const (
+	DocBlockParagraph DocBlockKind = "paragraph"
+	DocBlockHeading   DocBlockKind = "heading"
+	DocBlockList      DocBlockKind = "list"
+	DocBlockCode      DocBlockKind = "code"
+)
+
+// DocBlock is a structured representation of a single block of a Go doc comment.
+// It allows renderers to present the documentation without parsing Markdown.
+type DocBlock struct {
+	Kind DocBlockKind `json:"kind"`
+	// Text is the plain text of a paragraph or heading, or the verbatim contents of a code block.
+	Text string `json:"text,omitempty"`
+	// Items contains the plain text of every list item.
+	Items []string `json:"items,omitempty"`
+	// Ordered is true for numbered lists.
+	Ordered bool `json:"ordered,omitempty"`
+}
+
+func newDocBlocks(doc *comment.Doc) []DocBlock {
+	if doc == nil {
+		return nil
+	}
+	blocks := make([]DocBlock, 0, len(doc.Content))
+	for _, block := range doc.Content {
+		switch b := block.(type) {
+		case *comment.Paragraph:
+			blocks = append(blocks, DocBlock{Kind: DocBlockParagraph, Text: commentTextToString(b.Text)})
+		case *comment.Heading:
+			blocks = append(blocks, DocBlock{Kind: DocBlockHeading, Text: commentTextToString(b.Text)})
+		case *comment.Code:
+			blocks = append(blocks, DocBlock{Kind: DocBlockCode, Text: b.Text})
+		case *comment.List:
+			list := DocBlock{Kind: DocBlockList, Items: make([]string, 0, len(b.Items))}
+			for _, item := range b.Items {
+				list.Ordered = list.Ordered || item.Number != ""
+				list.Items = append(list.Items, listItemToString(item))
+			}
+			blocks = append(blocks, list)
+		}
+	}
+	return blocks
+}
+
+func listItemToString(item *comment.ListItem) string {
+	paragraphs := make([]string, 0, len(item.Content))
+	for _, block := range item.Content {
+		if paragraph, ok := block.(*comment.Paragraph); ok {
+			paragraphs = append(paragraphs, commentTextToString(paragraph.Text))
+		}
+	}
+	return strings.Join(paragraphs, " ")
+}
+
+func commentTextToString(text []comment.Text) string {
+	var b strings.Builder
+	for _, t := range text {
+		switch v := t.(type) {
+		case comment.Plain:
+			b.WriteString(string(v))
+		case comment.Italic:
+			b.WriteString(string(v))
+		case *comment.Link:
+			b.WriteString(commentTextToString(v.Text))
+		case *comment.DocLink:
+			b.WriteString(commentTextToString(v.Text))
+		}
+	}
+	return strings.ReplaceAll(b.String(), "\n", " ")
+}
+
+func cloneDocBlocks(blocks []DocBlock) []DocBlock {
+	if blocks == nil {
+		return nil
+	}
+	clone := make([]DocBlock, 0, len(blocks))
+	for _, block := range blocks {
+		block.Items = slices.Clone(block.Items)
+		clone = append(clone, block)
+	}
+	return clone
+}
@@ -0,0 +1,56 @@
+package govydoc
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+
+	"github.com/nieomylnieja/govydoc/internal/godoc"
+)
+
+// DocFormat is the format of [PropertyDoc.TypeDoc] and [PropertyDoc.FieldDoc].
+type DocFormat string
+
+// Supported [DocFormat] values.
+const (
+	// DocMarkdown renders Go doc comments as Markdown, it is the default format.
+	DocMarkdown DocFormat = "markdown"
+	// DocHTML renders Go doc comments as HTML fragments.
+	DocHTML DocFormat = "html"
+	// DocPlain renders Go doc comments as plain text.
+	DocPlain DocFormat = "plain"
+)
+
+func (f DocFormat) validate() error {
+	switch f {
+	case DocMarkdown, DocHTML, DocPlain:
+		return nil
+	default:
+		return fmt.Errorf("unsupported doc format %q", f)
+	}
+}
+
+func (f DocFormat) render(doc godoc.Doc) string {
+	switch f {
+	case DocHTML:
+		return doc.HTMLDoc
+	case DocPlain:
+		return doc.TextDoc
+	default:
+		return doc.Doc
+	}
+}
+
+var markdownEscapeRegex = regexp.MustCompile(`\\(.)`)
+
+// unescape reverts the escaping of special characters applied to the text rendered in format f.
+func (f DocFormat) unescape(text string) string {
+	switch f {
+	case DocHTML:
+		return html.UnescapeString(text)
+	case DocPlain:
+		return text
+	default:
+		return markdownEscapeRegex.ReplaceAllString(text, "$1")
+	}
+}
@@ -0,0 +1,85 @@
+package govydoc
+
+import (
+	"go/token"
+	"regexp"
+	"strings"
+
+	"github.com/nieomylnieja/govydoc/internal/godoc"
+)
+
+// WithDocLinkAnchors returns an option that points Go doc links to types documented in the same [ObjectDoc],
+// e.g. [Student] in the doc comment of Teacher which has students,
+// to in-document anchors, e.g. "#github-com-org-repo-models-Student", instead of the Go documentation server,
+// which makes the documentation self-contained and navigable.
+// Links to the members of such types, e.g. [Student.Name], point to the type's anchor as well.
+// The first property of every documented type has its [PropertyDoc.Anchor] set,
+// which [RenderMarkdown] renders as the target of the links.
+// Links to other types are not changed.
+func WithDocLinkAnchors() GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.docLinkAnchors = true
+		return options
+	}
+}
+
+var anchorInvalidCharsRegex = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// typeAnchor returns the anchor of the type identified by [PropertyDoc.key],
+// e.g. "github-com-org-repo-models-Student" for "github.com/org/repo/models.Student".
+func typeAnchor(key string) string {
+	return anchorInvalidCharsRegex.ReplaceAllString(key, "-")
+}
+
+// linkDocumentedTypes replaces the URLs of doc links to the types of doc's properties with in-document anchors
+// and sets [PropertyDoc.Anchor] of the first property of every such type, see [WithDocLinkAnchors].
+func linkDocumentedTypes(doc ObjectDoc, docLinkBaseURL string) ObjectDoc {
+	if docLinkBaseURL == "" {
+		docLinkBaseURL = godoc.DefaultDocLinkBaseURL
+	}
+	anchors := make(map[string]string)
+	for i, property := range doc.Properties {
+		// Only named types can be linked, unlike e.g. []Student.
+		if property.TypeInfo.Package == "" || !token.IsIdentifier(property.TypeInfo.Name) {
+			continue
+		}
+		if _, found := anchors[property.key()]; !found {
+			anchors[property.key()] = typeAnchor(property.key())
+			doc.Properties[i].Anchor = anchors[property.key()]
+		}
+	}
+	if len(anchors) == 0 {
+		return doc
+	}
+
+	// Doc links are rendered as the import path of the package followed by the symbol in the URL's fragment,
+	// e.g. https://pkg.go.dev/fmt#Stringer or https://pkg.go.dev/fmt#Stringer.String.
+	linkRegex := regexp.MustCompile(regexp.QuoteMeta(docLinkBaseURL+"/") + `([^\s"'()<>#]+)#(\w+)(?:\.\w+)?`)
+	link := func(text string) string {
+		if !strings.Contains(text, docLinkBaseURL) {
+			return text
+		}
+		return linkRegex.ReplaceAllStringFunc(text, func(url string) string {
+			match := linkRegex.FindStringSubmatch(url)
+			if anchor, found := anchors[match[1]+"."+match[2]]; found {
+				return "#" + anchor
+			}
+			return url
+		})
+	}
+	doc.PackageDoc = link(doc.PackageDoc)
+	for i, property := range doc.Properties {
+		property.TypeDoc = link(property.TypeDoc)
+		property.FieldDoc = link(property.FieldDoc)
+		property.DeprecatedDoc = link(property.DeprecatedDoc)
+		property.TypeDeprecatedDoc = link(property.TypeDeprecatedDoc)
+		for j := range property.Methods {
+			property.Methods[j].Doc = link(property.Methods[j].Doc)
+		}
+		for j := range property.EnumConstants {
+			property.EnumConstants[j].Doc = link(property.EnumConstants[j].Doc)
+		}
+		doc.Properties[i] = property
+	}
+	return doc
+}
@@ -0,0 +1,73 @@
+package govydoc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nobl9/govy/pkg/govy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nieomylnieja/govydoc/internal/testmodels"
+)
+
+func TestWithDocLinkAnchors(t *testing.T) {
+	validator := govy.New[testmodels.Teacher]().WithName("Teacher")
+	const (
+		teacherAnchor    = "github-com-nieomylnieja-govydoc-internal-testmodels-Teacher"
+		studentAnchor    = "github-com-nieomylnieja-govydoc-internal-testmodels-Student"
+		universityAnchor = "github-com-nieomylnieja-govydoc-internal-testmodels-moremodels-University"
+	)
+
+	t.Run("markdown", func(t *testing.T) {
+		doc, err := Generate(validator, WithDocLinkAnchors())
+		require.NoError(t, err)
+
+		root := findProperty(t, doc, "$")
+		assert.Equal(t, teacherAnchor, root.Anchor)
+		assert.Equal(t, "Teacher is a sample struct used for testing. "+
+			"Spoiler alert: it has [Student](#"+studentAnchor+"). "+
+			"[Student.Name](#"+studentAnchor+") is the name of the student.\n\n"+
+			"Teacher attends [moremodels.University](#"+universityAnchor+").",
+			root.TypeDoc)
+		students := findProperty(t, doc, "$.students[*]")
+		assert.Equal(t, studentAnchor, students.Anchor)
+		assert.Contains(t, students.TypeDoc, "[fmt.Stringer](#fmt-Stringer)")
+		assert.Contains(t, students.TypeDoc, "[Teacher](#"+teacherAnchor+")")
+		assert.Contains(t, students.TypeDoc, "[this site](https://example.com)")
+		assert.Empty(t, findProperty(t, doc, "$.students").Anchor)
+		assert.Contains(t, findProperty(t, doc, "$.stringer").TypeDoc, "[Print](https://pkg.go.dev/fmt#Print)")
+		assert.Contains(t, doc.PackageDoc, "[Teacher](#"+teacherAnchor+")")
+
+		markdown, err := RenderMarkdown(doc)
+		require.NoError(t, err)
+		assert.Contains(t, markdown, "\n#### <a id=\""+studentAnchor+"\"></a>`$.students[*]`\n")
+		assert.Equal(t, 1, strings.Count(markdown, `<a id="`+studentAnchor+`">`))
+	})
+
+	t.Run("html with custom base URL", func(t *testing.T) {
+		doc, err := Generate(
+			validator,
+			WithDocLinkAnchors(),
+			WithDocFormat(DocHTML),
+			WithDocLinkBaseURL("https://godoc.example.com"),
+		)
+		require.NoError(t, err)
+
+		assert.Contains(t, findProperty(t, doc, "$").TypeDoc, `<a href="#`+studentAnchor+`">Student</a>`)
+	})
+
+	t.Run("not documented types", func(t *testing.T) {
+		doc, err := Generate(validator, WithDocLinkAnchors(), WithIncludedPaths("$.name"))
+		require.NoError(t, err)
+
+		assert.Contains(t, findProperty(t, doc, "$").TypeDoc,
+			"[Student](https://pkg.go.dev/github.com/nieomylnieja/govydoc/internal/testmodels#Student)")
+	})
+
+	t.Run("sequence", func(t *testing.T) {
+		_, err := GenerateSeq(validator, WithDocLinkAnchors())
+		require.EqualError(t, err, "cannot generate properties sequence with doc link anchors: "+
+			"linking requires all the documented types to be known first")
+	})
+}
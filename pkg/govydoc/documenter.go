@@ -0,0 +1,30 @@
+package govydoc
+
+import "reflect"
+
+// Documenter is implemented by types which describe themselves,
+// e.g. dynamic or generated types which have no Go doc comment.
+// See [WithDocumenterInterface].
+type Documenter interface {
+	// GovydocDescription returns the documentation of the type.
+	GovydocDescription() string
+}
+
+var documenterType = reflect.TypeFor[Documenter]()
+
+// documenterDescription returns the description of typ if either typ or a pointer to typ implements [Documenter].
+// The method is called on a zero value of typ.
+// Interface types are not described, as they have no value to call the method on.
+func documenterDescription(typ reflect.Type) string {
+	if typ.Kind() == reflect.Interface {
+		return ""
+	}
+	value := reflect.New(typ)
+	if documenter, ok := value.Interface().(Documenter); ok {
+		return documenter.GovydocDescription()
+	}
+	if documenter, ok := value.Elem().Interface().(Documenter); ok {
+		return documenter.GovydocDescription()
+	}
+	return ""
+}
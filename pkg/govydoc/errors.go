@@ -0,0 +1,17 @@
+package govydoc
+
+import "github.com/nieomylnieja/govydoc/internal/godoc"
+
+// PackageLoadError is an error reported by the Go toolchain for a package of the documented module
+// or its dependencies, e.g. a syntax or type error.
+// Its Path is the import path of the package.
+type PackageLoadError = godoc.PackageLoadError
+
+// ModuleLoadError is an error reported by the Go toolchain for a module, e.g. a missing go.sum entry.
+// Its Path is the module path.
+type ModuleLoadError = godoc.ModuleLoadError
+
+// LoadErrors aggregates the [PackageLoadError] and [ModuleLoadError] errors encountered
+// while loading the module's packages, which is returned, wrapped, by [Generate] and [NewGenerator].
+// Use [errors.As] to find a specific error.
+type LoadErrors = godoc.LoadErrors
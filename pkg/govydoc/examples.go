@@ -0,0 +1,165 @@
+package govydoc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/nobl9/govy/pkg/govy"
+	"github.com/nobl9/govy/pkg/jsonpath"
+
+	"github.com/nieomylnieja/govydoc/internal/godoc"
+)
+
+// WithExamples returns an option that attaches examples to [ObjectDoc.Examples].
+// The [Example.Content] is decoded from JSON into the documented type and validated with the validator
+// passed to [Generate], which sets [Example.Valid] and [Example.Errors].
+// It allows documenting both valid and invalid examples.
+func WithExamples(examples ...Example) GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.examples = append(options.examples, examples...)
+		return options
+	}
+}
+
+// defaultExampleReferenceMarker introduces a reference to an example file in a doc comment,
+// see [WithResolveExampleReferences].
+const defaultExampleReferenceMarker = "Example file:"
+
+// WithResolveExampleReferences returns an option that resolves references to example files
+// found in [PropertyDoc.TypeDoc] and [PropertyDoc.FieldDoc], and attaches the files to [ObjectDoc.Examples].
+// A reference is a paragraph starting with a marker followed by the file path, e.g. "Example file: teacher.yaml".
+// The marker defaults to "Example file:" and can be changed with [WithExampleReferenceMarker].
+// Paths are relative to dir, each referenced file becomes an [Example] named after its path,
+// and references are removed from the documentation.
+// Unlike the ones passed to [WithExamples], the examples are not validated, as they are not required to be JSON.
+// An error is returned if any of the referenced files cannot be read.
+func WithResolveExampleReferences(dir string) GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.exampleDir = dir
+		return options
+	}
+}
+
+// WithExampleReferenceMarker returns an option that sets the marker of example file references,
+// see [WithResolveExampleReferences].
+func WithExampleReferenceMarker(marker string) GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.exampleMarker = marker
+		return options
+	}
+}
+
+// WithGoExamples returns an option that attaches the testable example functions of the documented type,
+// declared in the test files of its package, e.g. ExampleTeacher or ExampleTeacher_json, to [ObjectDoc.Examples].
+// Each function becomes an [Example] named after the function, with its body as [Example.Content].
+// Like the referenced example files, the examples are not validated.
+func WithGoExamples() GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.goExamples = true
+		return options
+	}
+}
+
+// newGoExamples returns the examples of typ's declaration, see [WithGoExamples].
+func newGoExamples(typ reflect.Type, goDocs godoc.Docs) []Example {
+	goDoc := documentedTypeDoc(typ, goDocs)
+	examples := make([]Example, 0, len(goDoc.Examples))
+	for _, example := range goDoc.Examples {
+		examples = append(examples, Example{Name: example.Name, Content: example.Code})
+	}
+	return examples
+}
+
+// exampleReferences removes example file references from the documentation of properties
+// and records the referenced paths.
+type exampleReferences struct {
+	regex  *regexp.Regexp
+	format DocFormat
+	// paths are the referenced paths, in the order of their first reference.
+	paths []string
+}
+
+func newExampleReferences(options generateOptions) *exampleReferences {
+	return &exampleReferences{
+		// The paragraph opening tag of [DocHTML] format is matched as well.
+		regex: regexp.MustCompile(
+			`(?m)^(?:<p>)?` + regexp.QuoteMeta(options.exampleMarker) + `[ \t]*(\S+)[ \t]*$`),
+		format: options.docFormat,
+	}
+}
+
+// cut removes example file references from the type and field documentation of the property.
+func (e *exampleReferences) cut(property PropertyDoc) PropertyDoc {
+	property.TypeDoc = e.cutText(property.TypeDoc)
+	property.FieldDoc = e.cutText(property.FieldDoc)
+	return property
+}
+
+func (e *exampleReferences) cutText(text string) string {
+	for _, match := range e.regex.FindAllStringSubmatch(text, -1) {
+		if path := e.format.unescape(match[1]); !slices.Contains(e.paths, path) {
+			e.paths = append(e.paths, path)
+		}
+	}
+	return strings.TrimSpace(e.regex.ReplaceAllString(text, ""))
+}
+
+// read returns the referenced files from exampleDir as examples, in the order of their first reference.
+func (e *exampleReferences) read(exampleDir string) ([]Example, error) {
+	examples := make([]Example, 0, len(e.paths))
+	for _, path := range e.paths {
+		content, err := os.ReadFile(filepath.Join(exampleDir, path))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read referenced example file: %w", err)
+		}
+		examples = append(examples, Example{Name: path, Content: string(content)})
+	}
+	return examples, nil
+}
+
+func validateExamples[T any](validator govy.Validator[T], examples []Example) []Example {
+	validated := make([]Example, 0, len(examples))
+	for _, example := range examples {
+		var value T
+		if err := json.Unmarshal([]byte(example.Content), &value); err != nil {
+			example.Errors = []string{fmt.Sprintf("failed to decode example: %v", err)}
+		} else {
+			example.Errors = validationErrorMessages(validator.Validate(value))
+		}
+		valid := len(example.Errors) == 0
+		example.Valid = &valid
+		validated = append(validated, example)
+	}
+	return validated
+}
+
+// validationErrorMessages returns a message for every rule error,
+// prefixed with the path of the property which failed validation.
+func validationErrorMessages(err error) []string {
+	if err == nil {
+		return nil
+	}
+	var validatorErr *govy.ValidatorError
+	if !errors.As(err, &validatorErr) {
+		return []string{err.Error()}
+	}
+	var messages []string
+	for _, propertyErr := range validatorErr.Errors {
+		for _, ruleErr := range propertyErr.Errors {
+			if propertyErr.PropertyPath.IsEmpty() || propertyErr.PropertyPath.IsRoot() {
+				messages = append(messages, ruleErr.Message)
+				continue
+			}
+			path := jsonpath.NewRoot().Join(propertyErr.PropertyPath)
+			messages = append(messages, path.String()+": "+ruleErr.Message)
+		}
+	}
+	return messages
+}
@@ -1,8 +1,14 @@
 package govydoc
 
 import (
+	"cmp"
+	"context"
 	"fmt"
+	"maps"
+	"net/url"
 	"reflect"
+	"slices"
+	"strings"
 
 	"github.com/nobl9/govy/pkg/govy"
 	"github.com/nobl9/govy/pkg/jsonpath"
@@ -12,16 +18,46 @@ import (
 
 // ObjectDoc describes a Go type, its properties, and its validation documentation.
 type ObjectDoc struct {
-	Name       string        `json:"name"`
+	// Name is the name provided to [govy.Validator.WithName].
+	// If the validator has no name, it falls back to the Go type name.
+	// Type aliases are indistinguishable from their underlying types at runtime,
+	// hence an alias name is only used when set explicitly with [govy.Validator.WithName].
+	Name string `json:"name"`
+	// Kind classifies the documented object, e.g. "request" or "config", see [WithKind].
+	Kind       string        `json:"kind,omitempty"`
 	Properties []PropertyDoc `json:"properties"`
 	Examples   []Example     `json:"examples,omitempty,omitzero"`
 	Doc        string        `json:"doc,omitempty"`
+	// PackageDoc is the package comment of the package declaring the documented type,
+	// which usually gives an overview of the documented types.
+	PackageDoc string `json:"packageDoc,omitempty"`
+	// UnionGroups lists mutually exclusive sibling properties, see [WithUnionGroups].
+	UnionGroups []UnionGroup `json:"unionGroups,omitempty"`
+	// Metadata holds free-form information attached with [WithMetadata].
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// PlanWarnings lists validation plan diagnostics, e.g. rules of properties
+	// which could not be matched with any documented property and thus are not documented.
+	PlanWarnings []string `json:"planWarnings,omitempty"`
+	// DocWarnings lists Go documentation diagnostics, e.g. types whose declarations
+	// could not be found in the module's source and thus have no documentation.
+	DocWarnings []string `json:"docWarnings,omitempty"`
+	// Plan is the govy validation plan the documentation was generated from, as returned by [govy.Plan],
+	// for custom processing which needs more than [PropertyDoc.PropertyPlan].
+	// Unlike the plans of the properties, it is not affected by options like [WithNameMapping] or [WithFilteredRules],
+	// and it includes the plans of properties which are not documented.
+	// It is not encoded, and it is nil for documentation which was not generated, e.g. decoded from JSON.
+	Plan *govy.ValidatorPlan `json:"-"`
 }
 
 // Example describes a named usage example included in generated documentation.
 type Example struct {
 	Name    string `json:"name"`
 	Content string `json:"content"`
+	// Valid reports whether the example passed validation, see [WithExamples].
+	// It is nil if the example was not validated.
+	Valid *bool `json:"valid,omitempty"`
+	// Errors lists the validation errors of an invalid example.
+	Errors []string `json:"errors,omitempty"`
 }
 
 // PropertyDoc combines a govy property plan with its Go source documentation.
@@ -31,55 +67,459 @@ type PropertyDoc struct {
 	TypeDoc string `json:"typeDoc,omitempty"`
 	// FieldDoc contains the documentation attached to the struct field.
 	FieldDoc string `json:"fieldDoc,omitempty"`
-	// DeprecatedDoc contains the text following a Deprecated marker.
+	// RawTypeDoc contains the [PropertyDoc.TypeDoc] before it was converted to Markdown.
+	// It is only set when [WithRawDocs] is used.
+	RawTypeDoc string `json:"rawTypeDoc,omitempty"`
+	// RawFieldDoc contains the [PropertyDoc.FieldDoc] before it was converted to Markdown.
+	// It is only set when [WithRawDocs] is used.
+	RawFieldDoc string `json:"rawFieldDoc,omitempty"`
+	// TypeDocBlocks contains the structured form of [PropertyDoc.TypeDoc].
+	// It is only set when [WithDocBlocks] is used.
+	TypeDocBlocks []DocBlock `json:"typeDocBlocks,omitempty"`
+	// FieldDocBlocks contains the structured form of [PropertyDoc.FieldDoc].
+	// It is only set when [WithDocBlocks] is used.
+	FieldDocBlocks []DocBlock `json:"fieldDocBlocks,omitempty"`
+	// EnumValues lists the values of the go-enum ENUM(...) declaration found in the type's doc comment,
+	// e.g. Red, Green, and Blue for ENUM(Red=1, Green, Blue).
+	// The declaration is removed from [PropertyDoc.TypeDoc].
+	// See [PropertyDoc.AllowedValues] for how it relates to the other lists of values.
+	EnumValues []string `json:"enumValues,omitempty"`
+	// EnumConstants lists the exported constants declared with the property's type in its package,
+	// e.g. Red and Green for const (Red Color = "red"; Green Color = "green"), in the order of declaration.
+	// See [PropertyDoc.AllowedValues] for how it relates to the other lists of values.
+	EnumConstants []EnumConstant `json:"enumConstants,omitempty"`
+	// AllowedValues lists all valid values of the property, it is the list of values meant to be displayed.
+	// The values of a property can come from three sources, which are documented separately as well:
+	//   - [govy.PropertyPlan.Values] allowed by the validation rules, like [rules.OneOf],
+	//     which [Constraints.Enum] is set to, as the constraints only come from the rules
+	//   - [PropertyDoc.EnumValues] declared with go-enum in the doc comment of the property's type
+	//   - [PropertyDoc.EnumConstants] declared with the property's type in its package
+	//
+	// AllowedValues is set from the first of the sources, in this order, which is not empty,
+	// so the values allowed by the rules take precedence over the values declared with the type.
+	AllowedValues []string `json:"allowedValues,omitempty"`
+	// DeprecatedDoc contains the text following the Deprecated marker of the field's doc comment,
+	// e.g. "Use Name instead." for a field documented with "Deprecated: Use Name instead.".
 	DeprecatedDoc string `json:"deprecatedDoc,omitempty"`
+	// TypeDeprecatedDoc contains the text following the Deprecated marker of the type's doc comment.
+	// Unlike [PropertyDoc.DeprecatedDoc], it tells that the property's type is deprecated,
+	// not the property itself.
+	TypeDeprecatedDoc string `json:"typeDeprecatedDoc,omitempty"`
 	// ChildrenPaths contains the JSON paths of the property's immediate children.
 	ChildrenPaths []string `json:"childrenPaths,omitempty,omitzero"`
+	// PathRole tells what the property's path points to, e.g. a map key, see [PathRole].
+	PathRole PathRole `json:"pathRole,omitempty"`
+	// RecursiveRef is the path of the ancestor property of the same type, for properties of recursive types,
+	// like the next node of a linked list node.
+	// Such properties are documented as leaves, as their nested properties are documented under the ancestor.
+	RecursiveRef string `json:"recursiveRef,omitempty"`
+	// Truncated is true for properties whose nested properties are not documented,
+	// as they are deeper than the depth set with [WithMaxDepth].
+	Truncated bool `json:"truncated,omitempty"`
+	// Hidden is true for properties which are not encoded, documented with [WithIncludeHidden]
+	// or [WithIncludeUnexported], and for their descendants.
+	Hidden bool `json:"hidden,omitempty"`
+	// DefaultValue is the value of the struct tag set with [WithDefaultTag].
+	DefaultValue string `json:"defaultValue,omitempty"`
+	// Default is the [PropertyDoc.DefaultValue] parsed according to the property's kind,
+	// that is, as an int64, uint64, float64, or bool for properties of these kinds.
+	// It is the unparsed string for properties of other kinds, and for values which cannot be parsed.
+	Default any `json:"default,omitempty"`
+	// Required is true for properties with an unconditional required rule,
+	// that is, a rule with the "required" error code and no conditions.
+	// Rules excluded with [WithFilteredRules] or [WithRuleFilter] are not taken into account.
+	Required bool `json:"required,omitempty"`
+	// IsInterface is true for properties of interface types, whose values can be of any type implementing them.
+	// Their implementations can be documented with [WithInterfaceImplementations].
+	IsInterface bool `json:"isInterface,omitempty"`
+	// AllowsNull is true for pointer, slice, and map properties which can be nil,
+	// that is, which do not have an unconditional required rule.
+	AllowsNull bool `json:"allowsNull,omitempty"`
+	// AllowsEmpty is true for slice and map properties which can have no elements,
+	// that is, which do not have an unconditional rule requiring a positive minimum length.
+	AllowsEmpty bool `json:"allowsEmpty,omitempty"`
+	// FieldSize is the size in bytes of the struct field's type.
+	// It is only set when [WithLayoutInfo] is used.
+	FieldSize int `json:"fieldSize,omitempty"`
+	// FieldOffset is the offset in bytes of the struct field within its parent struct.
+	// It is only set when [WithLayoutInfo] is used, and, being zero for the first field, omitted from JSON then.
+	FieldOffset int `json:"fieldOffset,omitempty"`
+	// SourcePos is the position of the struct field's declaration in Go source.
+	// It is only set when [WithSourcePositions] is used, and only for struct fields found in the module's packages.
+	SourcePos SourcePos `json:"sourcePos,omitzero"`
+	// Methods lists the methods of interface properties when [WithInterfaceMethods] is used,
+	// and the methods declared for the type of other properties when [WithTypeMethods] is used.
+	Methods []MethodInfo `json:"methods,omitempty"`
+	// ValidatorName is the name of the property set with [govy.PropertyRules.WithName],
+	// if it differs from the property's JSON name.
+	// Since the validation plan is only matched with properties by their paths, this can only happen
+	// when the plan path was renamed with [WithNameMapping], or when the name uses nested notation,
+	// e.g. "address.city" documented at "$.address.city".
+	// It is empty otherwise, including for properties without a validation plan.
+	ValidatorName string `json:"validatorName,omitempty"`
+	// Constraints aggregates the constraints recognized from [govy.PropertyPlan.Rules].
+	// It is nil if no constraints were recognized.
+	Constraints *Constraints `json:"constraints,omitempty"`
+	// RuleDocs describes [govy.PropertyPlan.Rules] with their parameters, like the bounds of length rules,
+	// independently of govy's plan types.
+	RuleDocs []RuleDoc `json:"ruleDocs,omitempty"`
+	// Conditions lists the distinct descriptions of the predicates [govy.PropertyPlan.Rules] are applied under,
+	// e.g. set with [govy.WhenDescription], in the order of their first occurrence.
+	// The rules guarded by each condition are described by [RuleDoc.Conditions].
+	Conditions []string `json:"conditions,omitempty"`
+	// Anchor is the in-document anchor of the property's type, which doc links point to.
+	// It is only set for the first property of every type when [WithDocLinkAnchors] is used.
+	Anchor string `json:"anchor,omitempty"`
+	// ID identifies the property independently of its path, see [WithStableIDs].
+	ID string `json:"id,omitempty"`
+	// StructTag is the tag of the struct field the property was mapped from.
+	// It is empty for properties which are not struct fields, like the root or slice elements.
+	StructTag reflect.StructTag `json:"-"`
 }
 
+// SourcePos is a position in a Go source file.
+type SourcePos struct {
+	// File is the path of the file, relative to the module root if the file belongs to the module,
+	// e.g. "pkg/model/teacher.go".
+	// When multiple modules are documented, see [WithModuleRoots], it is relative to their common directory.
+	File string `json:"file"`
+	Line int    `json:"line"`
+}
+
+// MethodInfo describes a method of an interface property.
+type MethodInfo struct {
+	Name string `json:"name"`
+	// Signature is the method's signature, e.g. "String() string".
+	Signature string `json:"signature"`
+	Doc       string `json:"doc,omitempty"`
+}
+
+// EnumConstant describes a constant declared with the type of a property, like a value of an enum.
+type EnumConstant struct {
+	Name string `json:"name"`
+	// Value is the constant's value, e.g. "red" for Red Color = "red" and "1" for the second iota constant.
+	Value string `json:"value"`
+	// Doc is the constant's doc comment or, if it has none, its trailing line comment.
+	Doc string `json:"doc,omitempty"`
+}
+
+// PathRole describes what a property's path points to within its parent property.
+type PathRole string
+
+// Supported [PathRole] values.
+const (
+	// PathRoleRoot is the role of the root property, "$".
+	PathRoleRoot PathRole = "root"
+	// PathRoleField is the role of struct fields, e.g. "$.name".
+	PathRoleField PathRole = "field"
+	// PathRoleSliceItem is the role of slice elements, e.g. "$.items[*]".
+	PathRoleSliceItem PathRole = "sliceItem"
+	// PathRoleMapKey is the role of map keys, e.g. "$.labels.*~".
+	PathRoleMapKey PathRole = "mapKey"
+	// PathRoleMapValue is the role of map values, e.g. "$.labels.*".
+	PathRoleMapValue PathRole = "mapValue"
+	// PathRoleImplementation is the role of interface implementations, e.g. "$.channel['(EmailChannel)']",
+	// see [WithInterfaceImplementations].
+	PathRoleImplementation PathRole = "implementation"
+)
+
 // GenerateOption configures [Generate].
 type GenerateOption func(options generateOptions) generateOptions
 
 type generateOptions struct {
-	govyPlanOptions []govy.PlanOption
-	filterPaths     []jsonpath.Path
+	govyPlanOptions     []govy.PlanOption
+	includePaths        []jsonpath.Path
+	filterPaths         []jsonpath.Path
+	filterRules         []govy.ErrorCode
+	typeKinds           []string
+	ruleFilters         []func(RuleDoc) bool
+	propertyTransforms  []PropertyPostProcessor
+	unionGroups         bool
+	withoutMapKeys      bool
+	rawDocs             bool
+	arrayToken          string
+	sortedPaths         bool
+	maxDepth            *int
+	docBlocks           bool
+	stableIDs           func(PropertyDoc) string
+	nameMapping         map[string]string
+	exportedTypesOnly   bool
+	metadata            map[string]string
+	kind                string
+	docLinkBaseURL      string
+	docLinkAnchors      bool
+	docFormat           DocFormat
+	documenterInterface bool
+	typeDocOverrides    map[string]string
+	declarationOrder    bool
+	examples            []Example
+	untaggedFields      UntaggedFields
+	embeddedMode        EmbeddedMode
+	includeHidden       bool
+	includeUnexported   bool
+	trailingComments    bool
+	defaultTag          string
+	minimalOutput       bool
+	scalarTypes         map[reflect.Type]scalarType
+	scalarInterfaces    []scalarInterface
+	layoutInfo          bool
+	sourcePositions     bool
+	interfaceMethods    bool
+	// interfaceImplementations holds the registrations of [WithInterfaceImplementations],
+	// which are validated and mapped to implementations by their interfaces.
+	interfaceImplementations []interfaceImplementations
+	implementations          map[reflect.Type][]reflect.Type
+	typeMethods              bool
+	progress                 func(event ProgressEvent)
+	exampleDir               string
+	exampleMarker            string
+	goExamples               bool
+	cacheDir                 string
+	noCache                  bool
+	tagName                  string
+	lazyLoading              bool
+	moduleRoots              []string
 }
 
 // Generate returns documentation for the type handled by validator.
 // It returns an error when source documentation or the govy validation plan cannot be generated.
+// The type must be a named type declared in a package, like a struct, or a pointer, slice, array, or map of one,
+// including instantiated generic types, which is also verified when Generate is wrapped in generic functions.
+// The module's packages are loaded by the first call and reused by the subsequent ones,
+// use [NewGenerator] to control when they are loaded.
 func Generate[T any](validator govy.Validator[T], opts ...GenerateOption) (ObjectDoc, error) {
-	typ := reflect.TypeFor[T]()
+	return GenerateContext(context.Background(), validator, opts...)
+}
 
+// GenerateContext is like [Generate], but stops loading the module's packages when ctx is done,
+// in which case ctx's error is returned.
+func GenerateContext[T any](
+	ctx context.Context,
+	validator govy.Validator[T],
+	opts ...GenerateOption,
+) (ObjectDoc, error) {
+	if err := ctx.Err(); err != nil {
+		return ObjectDoc{}, err
+	}
+	options, err := newGenerateOptions(opts)
+	if err != nil {
+		return ObjectDoc{}, err
+	}
+	return generate(ctx, &validator, sharedParserLoader(ctx, reflect.TypeFor[T](), options), &sharedCaches, options)
+}
+
+// GenerateType is like [Generate], but documents T without a validator,
+// e.g. a configuration type whose validation is not implemented yet.
+// Only the structure and the Go documentation of T are documented,
+// as there is no validation plan, the properties have no rules and [ObjectDoc.Plan] is nil.
+// Examples passed to [WithExamples] are attached without being validated.
+func GenerateType[T any](opts ...GenerateOption) (ObjectDoc, error) {
+	options, err := newGenerateOptions(opts)
+	if err != nil {
+		return ObjectDoc{}, err
+	}
+	ctx := context.Background()
+	return generate[T](ctx, nil, sharedParserLoader(ctx, reflect.TypeFor[T](), options), &sharedCaches, options)
+}
+
+// sharedParserLoader returns a function loading the parser of the shared generator, see [Generate].
+func sharedParserLoader(ctx context.Context, typ reflect.Type, options generateOptions) func() (*godoc.Parser, error) {
+	return func() (*godoc.Parser, error) {
+		start := options.startProgress()
+		generator, err := getSharedGenerator(ctx, options.lazyLoading, options.moduleRoots)
+		if err != nil {
+			return nil, err
+		}
+		options.reportProgress(ProgressPackagesLoaded, typ, generator.goDocParser.NumPackages(), start)
+		return generator.goDocParser, nil
+	}
+}
+
+func newGenerateOptions(opts []GenerateOption) (generateOptions, error) {
 	options := generateOptions{}
 	for _, opt := range opts {
 		options = opt(options)
 	}
-
-	objectDoc := generateObjectDoc(typ)
-	goDocParser, err := godoc.NewParser()
+	if options.arrayToken != "" {
+		if err := validateArrayToken(options.arrayToken); err != nil {
+			return generateOptions{}, err
+		}
+	}
+	if options.maxDepth != nil && *options.maxDepth < 0 {
+		return generateOptions{}, fmt.Errorf("invalid max depth %d: depth must not be negative", *options.maxDepth)
+	}
+	if options.docFormat == "" {
+		options.docFormat = DocMarkdown
+	}
+	if options.docLinkBaseURL != "" {
+		if err := validateDocLinkBaseURL(options.docLinkBaseURL); err != nil {
+			return generateOptions{}, err
+		}
+		options.docLinkBaseURL = strings.TrimSuffix(options.docLinkBaseURL, "/")
+	}
+	if err := options.docFormat.validate(); err != nil {
+		return generateOptions{}, err
+	}
+	options.scalarTypes = withDefaultScalarTypes(options.scalarTypes)
+	scalarInterfaces, err := withDefaultScalarInterfaces(options.scalarInterfaces)
 	if err != nil {
-		return ObjectDoc{}, fmt.Errorf("failed to create Go documentation parser: %w", err)
+		return generateOptions{}, err
 	}
-	goDoc, err := goDocParser.Parse(typ)
+	options.scalarInterfaces = scalarInterfaces
+	implementations, err := newInterfaceImplementations(options.interfaceImplementations)
 	if err != nil {
-		return ObjectDoc{}, fmt.Errorf("failed to parse documentation for %s: %w", typ, err)
+		return generateOptions{}, err
+	}
+	options.implementations = implementations
+	if options.exampleMarker == "" {
+		options.exampleMarker = defaultExampleReferenceMarker
+	}
+	if options.tagName == "" {
+		options.tagName = godoc.DefaultTagName
+	}
+	return options, nil
+}
+
+// generate documents T with the validation plan of validator, or without it if validator is nil.
+// Loading packages while parsing the Go documentation is stopped when ctx is done.
+func generate[T any](
+	ctx context.Context,
+	validator *govy.Validator[T],
+	loadParser func() (*godoc.Parser, error),
+	caches *documentationCaches,
+	options generateOptions,
+) (ObjectDoc, error) {
+	typ := reflect.TypeFor[T]()
+	if err := validateDocumentedType(typ); err != nil {
+		return ObjectDoc{}, err
 	}
 
-	plan, err := govy.Plan(validator, options.govyPlanOptions...)
+	objectDoc, err := generateObjectDoc(typ, options)
 	if err != nil {
-		return ObjectDoc{}, fmt.Errorf("failed to generate validation plan for %s: %w", typ, err)
+		return ObjectDoc{}, fmt.Errorf("failed to map properties of %s: %w", typ, err)
 	}
-	objectDoc.extendWithValidationPlan(plan)
+	goDoc, docWarnings, err := parseGoDoc(ctx, typ, loadParser, caches, options)
+	if err != nil {
+		return ObjectDoc{}, err
+	}
+	for _, impl := range documentedImplementations(objectDoc, options.implementations) {
+		implDoc, implWarnings, err := parseGoDoc(ctx, impl, loadParser, caches, options)
+		if err != nil {
+			return ObjectDoc{}, err
+		}
+		maps.Copy(goDoc, implDoc)
+		docWarnings = append(docWarnings, implWarnings...)
+	}
+	objectDoc.DocWarnings = docWarnings
+
+	var plan *govy.ValidatorPlan
+	if validator != nil {
+		start := options.startProgress()
+		plan, err = govy.Plan(*validator, options.govyPlanOptions...)
+		if err != nil {
+			return ObjectDoc{}, fmt.Errorf("failed to generate validation plan for %s: %w", typ, err)
+		}
+		options.reportProgress(ProgressPlanGenerated, typ, len(plan.Properties), start)
+		objectDoc.Plan = cloneValidatorPlan(plan)
+		if plan.Name != "" {
+			objectDoc.Name = plan.Name
+		}
+		objectDoc.PlanWarnings = planWarnings(plan, objectDoc.Properties, options.nameMapping)
+	}
+
+	// The properties are documented one at a time, the same way GenerateSeq documents them.
+	start := options.startProgress()
+	seq := newPropertySeq(plan, goDoc, options)
+	properties := make([]PropertyDoc, 0, len(objectDoc.Properties))
+	for _, property := range objectDoc.Properties {
+		if property, ok := seq.document(property); ok {
+			properties = append(properties, property)
+		}
+	}
+	objectDoc.Properties = properties
+	if !options.minimalOutput {
+		objectDoc.PackageDoc = packageDoc(typ, goDoc, options.docFormat)
+	}
+	if options.unionGroups {
+		objectDoc.UnionGroups = findUnionGroups(objectDoc.Properties)
+	}
+	if options.docLinkAnchors {
+		objectDoc = linkDocumentedTypes(objectDoc, options.docLinkBaseURL)
+	}
+	if options.sortedPaths {
+		sortProperties(objectDoc.Properties)
+	}
+	var referencedExamples []Example
+	if options.exampleDir != "" {
+		referencedExamples, err = seq.exampleReferences.read(options.exampleDir)
+		if err != nil {
+			return ObjectDoc{}, fmt.Errorf("failed to resolve example references of %s: %w", typ, err)
+		}
+	}
+	if len(options.metadata) > 0 {
+		objectDoc.Metadata = maps.Clone(options.metadata)
+	}
+	objectDoc.Kind = options.kind
+	if len(options.examples) > 0 && validator != nil {
+		objectDoc.Examples = validateExamples(*validator, options.examples)
+	} else if len(options.examples) > 0 {
+		objectDoc.Examples = slices.Clone(options.examples)
+	}
+	objectDoc.Examples = append(objectDoc.Examples, referencedExamples...)
+	if options.goExamples {
+		objectDoc.Examples = append(objectDoc.Examples, newGoExamples(typ, goDoc)...)
+	}
+	options.reportProgress(ProgressMergeDone, typ, len(objectDoc.Properties), start)
+	return objectDoc, nil
+}
 
-	mergeDocs(&objectDoc, goDoc)
-	objectDoc = postProcessProperties(
-		objectDoc,
-		options.filterPaths,
+// postProcessors returns the built-in post-processors applied to every property, in order.
+func postProcessors(options generateOptions) []PropertyPostProcessor {
+	return []PropertyPostProcessor{
+		filterRules(options.filterRules, options.ruleFilters),
+		setRequired,
 		removeEnumDeclaration,
 		extractDeprecatedInformation,
 		removeTrailingWhitespace,
-	)
-	return objectDoc, nil
+		aggregateConstraints,
+		setRuleDocs,
+		setConditions,
+		restrictNullability,
+		setAllowedValues,
+	}
+}
+
+// documentedTypeDoc returns the Go documentation of the named type of typ, see [validateDocumentedType].
+func documentedTypeDoc(typ reflect.Type, goDocs godoc.Docs) godoc.Doc {
+	for slices.Contains([]reflect.Kind{reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map}, typ.Kind()) {
+		typ = typ.Elem()
+	}
+	return goDocs[godoc.Doc{Name: typ.Name(), Package: typ.PkgPath()}.Key()]
+}
+
+// packageDoc renders the package comment of typ's package, see [ObjectDoc.PackageDoc].
+func packageDoc(typ reflect.Type, goDocs godoc.Docs, format DocFormat) string {
+	goDoc := documentedTypeDoc(typ, goDocs)
+	if goDoc.PackageDoc == nil {
+		return ""
+	}
+	return strings.TrimSpace(format.render(*goDoc.PackageDoc))
+}
+
+// validateDocumentedType checks if typ is a named type declared in a package, like a struct,
+// or a pointer, slice, array, or map of such type.
+// Other types, e.g. interface{} which a type parameter might have been instantiated with,
+// have no declaration to document and would result in meaningless documentation.
+func validateDocumentedType(typ reflect.Type) error {
+	elem := typ
+	for slices.Contains([]reflect.Kind{reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map}, elem.Kind()) {
+		elem = elem.Elem()
+	}
+	if elem.PkgPath() == "" {
+		return fmt.Errorf("cannot document %s: type must be a named type declared in a package,"+
+			" or a pointer, slice, array, or map of such type", typ)
+	}
+	return nil
 }
 
 // GenerateGovyOptions returns an option that passes govyOptions to [govy.Plan].
@@ -91,6 +531,9 @@ func GenerateGovyOptions(govyOptions ...govy.PlanOption) GenerateOption {
 }
 
 // WithFilteredPaths returns an option that excludes the supplied JSON paths from generated documentation.
+// Paths can also be patterns: name segments are matched with [path.Match], e.g. "$.metadata.internal*",
+// and the "**" segment matches any number of segments, e.g. "$.**.secret" matches "$.secret" and "$.a[*].secret".
+// Slice element ("[*]"), map key (".*~"), and map value (".*") segments only match themselves.
 func WithFilteredPaths(paths ...string) GenerateOption {
 	return func(options generateOptions) generateOptions {
 		for _, path := range paths {
@@ -100,6 +543,437 @@ func WithFilteredPaths(paths ...string) GenerateOption {
 	}
 }
 
+// WithIncludedPaths returns an option that limits generated documentation to the supplied JSON paths,
+// their ancestors, which keep the properties tree connected,
+// and the unnamed slice elements, map keys, and map values of the supplied paths, e.g. "$.items[*]" for "$.items".
+// Descendants of the supplied paths are not included unless listed explicitly.
+// [WithFilteredPaths] is applied after the inclusion.
+func WithIncludedPaths(paths ...string) GenerateOption {
+	return func(options generateOptions) generateOptions {
+		for _, path := range paths {
+			options.includePaths = append(options.includePaths, jsonpath.Parse(path))
+		}
+		return options
+	}
+}
+
+// WithTypeKindFilter returns an option that limits generated documentation to the properties
+// whose [govy.TypeInfo.Kind] is one of kinds, e.g. "string" or "[]int",
+// for instance to document only scalar leaf properties.
+// Properties of other kinds, including the root, are omitted, while their descendants of the listed kinds remain,
+// and [PropertyDoc.ChildrenPaths] refer only to the properties of the listed kinds.
+// The remaining descendants of an omitted property are listed in the ChildrenPaths of its nearest remaining ancestor,
+// e.g. "$.students[*]" in the ones of the root if "$.students" is omitted.
+// Subsequent calls add kinds.
+// [WithIncludedPaths] and [WithFilteredPaths] are applied along with the kind filter.
+func WithTypeKindFilter(kinds ...string) GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.typeKinds = append(slices.Clone(options.typeKinds), kinds...)
+		return options
+	}
+}
+
+// WithFilteredRules returns an option that excludes rules from the [govy.PropertyPlan.Rules] of every property,
+// e.g. internal rules which should not appear in public documentation.
+// Rules are matched by their [govy.ErrorCode], which identifies a rule in the validation plan,
+// including error codes chained with [govy.ErrorCode.Add].
+// Filtered rules are not taken into account when computing [PropertyDoc.Constraints] and nullability.
+func WithFilteredRules(errorCodes ...govy.ErrorCode) GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.filterRules = append(options.filterRules, errorCodes...)
+		return options
+	}
+}
+
+// WithRuleFilter returns an option that excludes the rules for which fn returns true
+// from the [govy.PropertyPlan.Rules] of every property, e.g. the rules which only scaffold conditional logic.
+// Rules are passed to fn as [RuleDoc], like they are documented in [PropertyDoc.RuleDocs].
+// Subsequent calls add filters, a rule is excluded if any of them returns true.
+// Like with [WithFilteredRules], filtered rules are not taken into account
+// when computing [PropertyDoc.Constraints] and nullability.
+func WithRuleFilter(fn func(RuleDoc) bool) GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.ruleFilters = append(slices.Clone(options.ruleFilters), fn)
+		return options
+	}
+}
+
+// WithPropertyTransform returns an option that modifies the documentation of every property with fn,
+// e.g. to redact secrets from the examples or to rewrite the doc comments.
+// Transformations are applied in the order they were added, and only to the properties which are not excluded
+// with [WithIncludedPaths], [WithFilteredPaths], or [WithTypeKindFilter].
+// They are applied last, when every field of the property is final, e.g. [PropertyDoc.Constraints],
+// the paths set with [WithArrayToken], or [PropertyDoc.ID] set with [WithStableIDs].
+// The only exceptions are [WithDocLinkAnchors], which rewrites the doc links of the transformed properties,
+// and [WithSortedPaths], which reorders them, as both require all the properties to be documented first.
+// [PropertyDoc.Path] and [PropertyDoc.ChildrenPaths] should be kept consistent across the properties.
+func WithPropertyTransform(fn PropertyPostProcessor) GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.propertyTransforms = append(slices.Clone(options.propertyTransforms), fn)
+		return options
+	}
+}
+
+// WithUnionGroups returns an option that records [UnionGroup] for every set of sibling properties
+// declared as mutually exclusive with govy's MutuallyExclusive rule.
+// It is useful for documenting unions modeled as structs with multiple pointer fields.
+// The groups are found after the properties and rules were filtered,
+// so they only reference documented properties and groups left with less than two of them are omitted.
+func WithUnionGroups() GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.unionGroups = true
+		return options
+	}
+}
+
+// WithoutMapKeys returns an option that omits the map key properties (e.g. "$.labels.*~")
+// from generated documentation, while keeping the map value properties (e.g. "$.labels.*").
+func WithoutMapKeys() GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.withoutMapKeys = true
+		return options
+	}
+}
+
+// WithMaxDepth returns an option that documents at most n levels of nesting below the root's fields.
+// The depth is measured like in [ObjectDoc.MaxDepth], that is, slice elements and map keys and values
+// do not add a level, so WithMaxDepth(0) documents only the root's immediate fields,
+// including the elements of slice and map fields.
+// The deepest documented properties whose nested properties are omitted are marked as [PropertyDoc.Truncated].
+// Validation rules of the omitted properties are not documented and do not produce [ObjectDoc.PlanWarnings].
+// [Generate] returns an error if n is negative.
+func WithMaxDepth(n int) GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.maxDepth = &n
+		return options
+	}
+}
+
+// WithRawDocs returns an option that additionally stores the original comment text,
+// before its conversion to Markdown, in [PropertyDoc.RawTypeDoc] and [PropertyDoc.RawFieldDoc].
+func WithRawDocs() GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.rawDocs = true
+		return options
+	}
+}
+
+// WithDocBlocks returns an option that additionally stores the documentation as a list of [DocBlock],
+// in [PropertyDoc.TypeDocBlocks] and [PropertyDoc.FieldDocBlocks].
+// The blocks reflect the original comment, including the parts which are extracted
+// from [PropertyDoc.TypeDoc] and [PropertyDoc.FieldDoc], like the Deprecated marker.
+func WithDocBlocks() GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.docBlocks = true
+		return options
+	}
+}
+
+// WithStableIDs returns an option that sets [PropertyDoc.ID] to the value returned by fn.
+// Unlike paths, such identifiers can remain stable when properties are renamed or moved,
+// for instance, fn can read the ID from a custom [PropertyDoc.StructTag].
+// If fn returns an empty string, the property's path is used instead.
+func WithStableIDs(fn func(PropertyDoc) string) GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.stableIDs = fn
+		return options
+	}
+}
+
+// WithNameMapping returns an option that maps validation plan paths to the paths derived from JSON tags.
+// It is useful when a property name set with [govy.PropertyRules.WithName] does not match the property's JSON name,
+// in which case its rules would otherwise not be documented.
+// Both keys and values are JSON paths, e.g. {"$.fullName": "$.name"}.
+// The mapping also applies to the descendants of the mapped paths,
+// with the longest mapped path applied if more than one matches, e.g. {"$.a": "$.x", "$.a.b": "$.y"}
+// maps "$.a.b.c" to "$.y.c".
+func WithNameMapping(mapping map[string]string) GenerateOption {
+	return func(options generateOptions) generateOptions {
+		if options.nameMapping == nil {
+			options.nameMapping = make(map[string]string, len(mapping))
+		}
+		maps.Copy(options.nameMapping, mapping)
+		return options
+	}
+}
+
+// WithExportedTypesOnly returns an option that documents properties of unexported named types as leaves,
+// without documenting their nested properties.
+// The root type is always documented in full.
+func WithExportedTypesOnly() GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.exportedTypesOnly = true
+		return options
+	}
+}
+
+// WithMetadata returns an option that attaches free-form metadata,
+// for example the owning team, to [ObjectDoc.Metadata].
+// Subsequent calls merge the metadata, overriding values of repeated keys.
+func WithMetadata(metadata map[string]string) GenerateOption {
+	return func(options generateOptions) generateOptions {
+		merged := maps.Clone(options.metadata)
+		if merged == nil {
+			merged = make(map[string]string, len(metadata))
+		}
+		maps.Copy(merged, metadata)
+		options.metadata = merged
+		return options
+	}
+}
+
+// WithKind returns an option that sets [ObjectDoc.Kind], a free-form classification of the documented object,
+// for example "request" or "response", which helps to tell apart objects in a catalog.
+func WithKind(kind string) GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.kind = kind
+		return options
+	}
+}
+
+// WithDocFormat returns an option that sets the [DocFormat] of [PropertyDoc.TypeDoc] and [PropertyDoc.FieldDoc].
+// Defaults to [DocMarkdown].
+func WithDocFormat(format DocFormat) GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.docFormat = format
+		return options
+	}
+}
+
+// WithDocLinkBaseURL returns an option that sets the base URL of the documentation
+// Go doc links, e.g. [fmt.Stringer], point to in [PropertyDoc.TypeDoc] and [PropertyDoc.FieldDoc],
+// e.g. a private Go documentation server hosting internal packages.
+// The base URL is followed by the import path of the linked package, like on https://pkg.go.dev, the default.
+// [Generate] returns an error if baseURL is not an absolute URL.
+func WithDocLinkBaseURL(baseURL string) GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.docLinkBaseURL = baseURL
+		return options
+	}
+}
+
+// validateDocLinkBaseURL checks if baseURL is an absolute URL, see [WithDocLinkBaseURL].
+func validateDocLinkBaseURL(baseURL string) error {
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return fmt.Errorf("invalid doc link base URL %q: %w", baseURL, err)
+	}
+	if parsed.Scheme == "" || parsed.Host == "" {
+		return fmt.Errorf("invalid doc link base URL %q: URL must be absolute", baseURL)
+	}
+	return nil
+}
+
+// WithDocumenterInterface returns an option that uses the description returned by [Documenter]
+// as [PropertyDoc.TypeDoc] of types which implement it and have no Go doc comment.
+// The description is used as is, regardless of the [DocFormat].
+func WithDocumenterInterface() GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.documenterInterface = true
+		return options
+	}
+}
+
+// WithTypeDocOverride returns an option that sets [PropertyDoc.TypeDoc] of properties of the type
+// named typeName declared in the pkgPath package, e.g. "time" and "Duration", to doc.
+// It documents types whose doc comments cannot be edited, like types of third-party packages.
+// Overrides take precedence over doc comments, [WithScalarType] descriptions, and the [Documenter] interface.
+// The doc is used as is, regardless of the [DocFormat].
+// Built-in types are matched with an empty pkgPath.
+func WithTypeDocOverride(pkgPath, typeName, doc string) GenerateOption {
+	return func(options generateOptions) generateOptions {
+		overrides := maps.Clone(options.typeDocOverrides)
+		if overrides == nil {
+			overrides = make(map[string]string, 1)
+		}
+		key := PropertyDoc{PropertyPlan: govy.PropertyPlan{TypeInfo: govy.TypeInfo{Name: typeName, Package: pkgPath}}}
+		overrides[key.key()] = doc
+		options.typeDocOverrides = overrides
+		return options
+	}
+}
+
+// WithDeclarationOrder returns an option that orders [PropertyDoc.ChildrenPaths] of struct properties
+// to follow the declaration order of the struct fields in Go source.
+// Slice elements listed among the children, e.g. $.items[*], directly follow their slice field.
+func WithDeclarationOrder() GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.declarationOrder = true
+		return options
+	}
+}
+
+// UntaggedFields controls how exported struct fields without a JSON name are documented,
+// see [WithUntaggedFields].
+type UntaggedFields int
+
+// Supported [UntaggedFields] values.
+const (
+	// UntaggedFieldsSkip omits untagged fields from the documentation, it is the default.
+	UntaggedFieldsSkip UntaggedFields = iota
+	// UntaggedFieldsUseFieldName documents untagged fields under their Go field name,
+	// which is the name [encoding/json] uses for them.
+	UntaggedFieldsUseFieldName
+)
+
+// WithTagName returns an option that reads the names of struct fields from the tagName struct tag,
+// e.g. "yaml", instead of the "json" tag.
+// Tag options, like omitempty, are ignored, and embedded structs without a name in the tag are promoted
+// to their parent struct, like they are by [encoding/json].
+func WithTagName(tagName string) GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.tagName = tagName
+		return options
+	}
+}
+
+// WithUntaggedFields returns an option that controls how exported struct fields without a JSON name,
+// either untagged or with a tag like `json:",omitempty"`, are documented.
+// Embedded structs are not affected, see [WithEmbeddedMode].
+func WithUntaggedFields(mode UntaggedFields) GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.untaggedFields = mode
+		return options
+	}
+}
+
+// EmbeddedMode controls how fields of embedded structs are documented, see [WithEmbeddedMode].
+type EmbeddedMode int
+
+// Supported [EmbeddedMode] values.
+const (
+	// EmbeddedModeFlatten promotes the fields of embedded structs to their parent struct,
+	// like [encoding/json] does, it is the default.
+	EmbeddedModeFlatten EmbeddedMode = iota
+	// EmbeddedModeNest documents exported embedded structs without a name in the tag
+	// as nested objects named after their Go field, e.g. $.Address.city.
+	EmbeddedModeNest
+)
+
+// WithEmbeddedMode returns an option that controls how fields of embedded structs are documented.
+// Embedded structs with a name in the tag are always documented as nested objects under that name,
+// and unexported embedded structs are always flattened.
+func WithEmbeddedMode(mode EmbeddedMode) GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.embeddedMode = mode
+		return options
+	}
+}
+
+// WithIncludeHidden returns an option that documents struct fields ignored with the "-" tag name, e.g. `json:"-"`,
+// under their Go field names, which is useful for internal documentation.
+// Such properties, and their descendants, are marked with [PropertyDoc.Hidden].
+func WithIncludeHidden() GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.includeHidden = true
+		return options
+	}
+}
+
+// WithIncludeUnexported returns an option that documents unexported struct fields under their Go field names,
+// like [WithIncludeHidden] does for ignored fields.
+// Unexported embedded structs are still flattened, see [WithEmbeddedMode].
+func WithIncludeUnexported() GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.includeUnexported = true
+		return options
+	}
+}
+
+// WithTrailingComments returns an option that documents struct fields without a doc comment
+// with their trailing line comment, e.g. `Age int // Age of the teacher.`, in [PropertyDoc.FieldDoc].
+// Doc comments placed above fields take precedence.
+func WithTrailingComments() GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.trailingComments = true
+		return options
+	}
+}
+
+// WithDefaultTag returns an option that sets [PropertyDoc.DefaultValue] and [PropertyDoc.Default] from the struct tag
+// with the given key, e.g. `default:"8080"` for key "default", which is used by configuration libraries like envconfig.
+func WithDefaultTag(key string) GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.defaultTag = key
+		return options
+	}
+}
+
+// WithInterfaceMethods returns an option that sets [PropertyDoc.Methods] of interface properties
+// to the methods of their type, including the methods of embedded interfaces.
+func WithInterfaceMethods() GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.interfaceMethods = true
+		return options
+	}
+}
+
+// WithTypeMethods returns an option that sets [PropertyDoc.Methods] of non-interface properties
+// to the exported methods declared with their type as the receiver, either by value or by pointer,
+// which documents types whose semantics are described by their methods, like [fmt.Stringer] implementations.
+// Methods promoted from embedded types are not included.
+func WithTypeMethods() GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.typeMethods = true
+		return options
+	}
+}
+
+// WithLayoutInfo returns an option that sets [PropertyDoc.FieldSize] and [PropertyDoc.FieldOffset]
+// of struct field properties, which helps documenting memory layout of performance-sensitive structs.
+// The layout is platform-dependent, it is reported for the platform running [Generate].
+// Fields promoted from structs embedded by pointer are not laid out in their parent struct,
+// hence their layout is not set.
+func WithLayoutInfo() GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.layoutInfo = true
+		return options
+	}
+}
+
+// WithSourcePositions returns an option that sets [PropertyDoc.SourcePos] of struct field properties
+// to the position of the field's declaration, which lets editor tooling navigate from the documentation to the source.
+func WithSourcePositions() GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.sourcePositions = true
+		return options
+	}
+}
+
+// WithMinimalOutput returns an option that strips all documentation text from [ObjectDoc],
+// including the type, field, raw, structured, and deprecation docs of every property.
+// Paths, type information, and validation rules are kept,
+// which is useful for machine consumers like schema generators.
+func WithMinimalOutput() GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.minimalOutput = true
+		return options
+	}
+}
+
+// WithArrayToken returns an option that replaces govy's "[*]" slice element token with token
+// in every generated path, e.g. "$.items[]" instead of "$.items[*]".
+// The replacement is applied last, paths passed to other options must use the "[*]" token.
+// [Generate] returns an error if token cannot be parsed back into the same JSON path.
+func WithArrayToken(token string) GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.arrayToken = token
+		return options
+	}
+}
+
+// WithSortedPaths returns an option that sorts [ObjectDoc.Properties] by their paths, which makes the output
+// independent of the declaration order of struct fields and the order of validation rules, e.g. for diffs.
+// Paths are compared segment by segment, so that properties are followed by their descendants,
+// e.g. "$.a", "$.a.b", "$.b".
+// Struct fields are sorted by name and precede slice elements, map keys, and map values, in this order.
+func WithSortedPaths() GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.sortedPaths = true
+		return options
+	}
+}
+
 func (p PropertyDoc) key() string {
 	if p.TypeInfo.Package == "" {
 		return p.TypeInfo.Name
@@ -107,41 +981,250 @@ func (p PropertyDoc) key() string {
 	return p.TypeInfo.Package + "." + p.TypeInfo.Name
 }
 
-func mergeDocs(objectDoc *ObjectDoc, goDocs godoc.Docs) {
-	for i, property := range objectDoc.Properties {
-		if property.TypeInfo.Package == "" {
+func newMethodInfos(methods []godoc.Method, format DocFormat) []MethodInfo {
+	if len(methods) == 0 {
+		return nil
+	}
+	infos := make([]MethodInfo, 0, len(methods))
+	for _, method := range methods {
+		infos = append(infos, MethodInfo{
+			Name:      method.Name,
+			Signature: method.Signature,
+			Doc:       strings.TrimSpace(format.render(method.Doc)),
+		})
+	}
+	return infos
+}
+
+func newEnumConstants(constants []godoc.EnumConstant, format DocFormat) []EnumConstant {
+	if len(constants) == 0 {
+		return nil
+	}
+	enumConstants := make([]EnumConstant, 0, len(constants))
+	for _, constant := range constants {
+		enumConstants = append(enumConstants, EnumConstant{
+			Name:  constant.Name,
+			Value: constant.Value,
+			Doc:   strings.TrimSpace(format.render(constant.Doc)),
+		})
+	}
+	return enumConstants
+}
+
+// propertyGoDoc returns the Go documentation of the property's type, if it is declared in a package.
+func propertyGoDoc(property PropertyDoc, goDocs godoc.Docs) (godoc.Doc, bool) {
+	if property.TypeInfo.Package == "" {
+		return godoc.Doc{}, false
+	}
+	goDoc, found := goDocs[property.key()]
+	return goDoc, found
+}
+
+// anonymousStructDoc returns the documentation of the property's anonymous struct type,
+// e.g. Config struct { Host string }, which is the documentation of the struct field declared with it.
+// The fields declared with anonymous struct types are held in anonymousStructs by their paths,
+// which the paths of slice elements, e.g. "$.items[*]", and map values are resolved to.
+func anonymousStructDoc(property PropertyDoc, anonymousStructs map[string]godoc.Doc) (godoc.Doc, bool) {
+	if property.TypeInfo.Package != "" || property.TypeInfo.Kind != "struct" {
+		return godoc.Doc{}, false
+	}
+	goDoc, found := anonymousStructs[trimWildcardSegments(property.Path.String())]
+	return goDoc, found
+}
+
+// mergeAnonymousStructDoc orders the children of the property of an anonymous struct type,
+// which has no documentation of its own, see [anonymousStructDoc].
+func mergeAnonymousStructDoc(property PropertyDoc, goDoc godoc.Doc, options generateOptions) PropertyDoc {
+	if options.declarationOrder {
+		property.ChildrenPaths = sortByDeclarationOrder(property.Path, property.ChildrenPaths, goDoc.FieldOrder)
+	}
+	return property
+}
+
+// mergeTypeDoc sets the documentation of the property's type from goDoc.
+func mergeTypeDoc(
+	property PropertyDoc,
+	goDoc godoc.Doc,
+	scalarDescriptions map[string]string,
+	options generateOptions,
+) PropertyDoc {
+	// Descriptions of scalar types take precedence over Go doc comments, see WithScalarType.
+	// Documentation set through the Documenter interface is only used when there is no Go doc comment.
+	if description, isScalar := scalarDescriptions[property.key()]; isScalar {
+		property.TypeDoc = description
+	} else if typeDoc := options.docFormat.render(goDoc); typeDoc != "" || property.TypeDoc == "" {
+		property.TypeDoc = typeDoc
+		property.EnumValues = parseEnumValues(goDoc.RawDoc)
+	}
+	property.EnumConstants = newEnumConstants(goDoc.EnumConstants, options.docFormat)
+	if options.rawDocs {
+		property.RawTypeDoc = goDoc.RawDoc
+	}
+	if options.docBlocks {
+		property.TypeDocBlocks = newDocBlocks(goDoc.Comment)
+	}
+	if options.interfaceMethods {
+		property.Methods = newMethodInfos(goDoc.Methods, options.docFormat)
+	}
+	if options.typeMethods && len(goDoc.DeclaredMethods) > 0 {
+		property.Methods = newMethodInfos(goDoc.DeclaredMethods, options.docFormat)
+	}
+	if options.declarationOrder {
+		property.ChildrenPaths = sortByDeclarationOrder(property.Path, property.ChildrenPaths, goDoc.FieldOrder)
+	}
+	return property
+}
+
+// mergeFieldDoc sets the documentation of the struct field the property is declared with from field.
+func mergeFieldDoc(property PropertyDoc, field godoc.Doc, options generateOptions) PropertyDoc {
+	property.FieldDoc = options.docFormat.render(field)
+	if options.rawDocs {
+		property.RawFieldDoc = field.RawDoc
+	}
+	if options.docBlocks {
+		property.FieldDocBlocks = newDocBlocks(field.Comment)
+	}
+	if options.sourcePositions {
+		property.SourcePos = SourcePos(field.SourcePos)
+	}
+	return property
+}
+
+// applyTypeDocOverride replaces the type documentation of the property with the override of its type,
+// see [WithTypeDocOverride].
+func applyTypeDocOverride(property PropertyDoc, overrides map[string]string, rawDocs bool) PropertyDoc {
+	doc, ok := overrides[property.key()]
+	if !ok {
+		return property
+	}
+	property.TypeDoc = doc
+	property.TypeDocBlocks = nil
+	property.EnumValues = parseEnumValues(doc)
+	if rawDocs {
+		property.RawTypeDoc = doc
+	}
+	return property
+}
+
+// renamePlanPath maps the validation plan path using the mapping set with [WithNameMapping].
+// If more than one of the mapped paths is a prefix of path, the longest one is applied.
+func renamePlanPath(path jsonpath.Path, mapping map[string]string) jsonpath.Path {
+	pathString := path.String()
+	renamed, longest := path, ""
+	for from, to := range mapping {
+		rest, found := strings.CutPrefix(pathString, from)
+		if !found || (rest != "" && rest[0] != '.' && rest[0] != '[') || len(from) <= len(longest) {
+			continue
+		}
+		renamed, longest = jsonpath.Parse(to+rest), from
+	}
+	return renamed
+}
+
+// planWarnings returns a warning for every property of the validation plan
+// which does not match any of the mapped properties, see [ObjectDoc.PlanWarnings].
+// The properties nested in [PropertyDoc.Truncated] properties are not mapped and are skipped.
+func planWarnings(plan *govy.ValidatorPlan, properties []PropertyDoc, nameMapping map[string]string) []string {
+	mapped := make(map[string]PropertyDoc, len(properties))
+	for _, property := range properties {
+		mapped[property.Path.String()] = property
+	}
+	var warnings []string
+	for _, propPlan := range plan.Properties {
+		path := renamePlanPath(propPlan.Path, nameMapping)
+		if _, found := mapped[path.String()]; found {
 			continue
 		}
-		goDoc, found := goDocs[property.key()]
-		if !found {
+		// Names set with WithName can use nested notation, e.g. "address.city".
+		if _, found := mapped[expandNestedNames(path).String()]; found {
 			continue
 		}
-		property.TypeDoc = goDoc.Doc
-		for name, field := range goDoc.StructFields {
-			fieldPath := property.Path.Name(name)
-			for j, p := range objectDoc.Properties {
-				if fieldPath.Equal(p.Path) {
-					objectDoc.Properties[j].FieldDoc = field.Doc
-					break
-				}
-			}
+		if isTruncated(path, mapped) {
+			continue
 		}
-		objectDoc.Properties[i] = property
+		warnings = append(warnings, fmt.Sprintf(
+			"validation plan property %s does not match any documented property, its %d rule(s) are not documented",
+			path, len(propPlan.Rules)))
 	}
+	return warnings
 }
 
-func (o *ObjectDoc) extendWithValidationPlan(plan *govy.ValidatorPlan) {
-	o.Name = plan.Name
-	for _, propPlan := range plan.Properties {
-		for i, propDoc := range o.Properties {
-			if !propPlan.Path.Equal(propDoc.Path) {
-				continue
-			}
-			o.Properties[i] = PropertyDoc{
-				PropertyPlan:  *propPlan,
-				ChildrenPaths: propDoc.ChildrenPaths,
+// applyPropertyPlan sets the validation plan of the property.
+// validatorName is the name of the property in the validator, before applying [WithNameMapping].
+func applyPropertyPlan(property PropertyDoc, plan govy.PropertyPlan, validatorName string) PropertyDoc {
+	property.PropertyPlan = plan
+	if validatorName != pathSegmentName(plan.Path) {
+		property.ValidatorName = validatorName
+	}
+	return property
+}
+
+// isTruncated reports whether path is nested in a [PropertyDoc.Truncated] property of the mapped properties.
+func isTruncated(path jsonpath.Path, mapped map[string]PropertyDoc) bool {
+	for parent, ok := parentPath(path.String()); ok; parent, ok = parentPath(parent) {
+		if property, found := mapped[parent]; found {
+			return property.Truncated
+		}
+	}
+	return false
+}
+
+// pathSegmentName returns the [segmentName] of the last segment of path,
+// e.g. "name" for "$.name" and "a.b" for "$['a.b']".
+// It returns an empty string for the root path.
+func pathSegmentName(path jsonpath.Path) string {
+	pathString := path.String()
+	parent, ok := parentPath(pathString)
+	if !ok {
+		return ""
+	}
+	return segmentName(pathString[len(parent):])
+}
+
+// expandNestedNames reinterprets names which are written in JSON path notation, e.g. "address.city"
+// or "items[*]", as separate segments of the path, e.g. $.address.city instead of $['address.city'].
+func expandNestedNames(path jsonpath.Path) jsonpath.Path {
+	s := path.String()
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if !strings.HasPrefix(s[i:], "['") {
+			b.WriteByte(s[i])
+			continue
+		}
+		var name strings.Builder
+		i += 2
+		for ; i < len(s) && s[i] != '\''; i++ {
+			if s[i] == '\\' && i+1 < len(s) {
+				i++
 			}
-			break
+			name.WriteByte(s[i])
+		}
+		// Skip the closing bracket.
+		i++
+		if !strings.HasPrefix(name.String(), "[") {
+			b.WriteByte('.')
+		}
+		b.WriteString(name.String())
+	}
+	return jsonpath.Parse(b.String())
+}
+
+// sortByDeclarationOrder sorts childrenPaths of the property at parent
+// according to the order of fieldNames.
+func sortByDeclarationOrder(parent jsonpath.Path, childrenPaths, fieldNames []string) []string {
+	order := make(map[string]int, len(fieldNames))
+	for i, name := range fieldNames {
+		order[parent.Name(name).String()] = i
+	}
+	rank := func(path string) int {
+		if i, found := order[path]; found {
+			return i
 		}
+		return len(fieldNames)
 	}
+	sorted := slices.Clone(childrenPaths)
+	slices.SortStableFunc(sorted, func(a, b string) int {
+		return cmp.Compare(rank(a), rank(b))
+	})
+	return sorted
 }
@@ -1,16 +1,26 @@
 package govydoc
 
 import (
+	"context"
 	_ "embed"
+	"encoding"
 	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"unsafe"
 
 	"github.com/nobl9/govy/pkg/govy"
+	"github.com/nobl9/govy/pkg/jsonpath"
 	"github.com/nobl9/govy/pkg/rules"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/nieomylnieja/govydoc/internal/testmodels"
+	"github.com/nieomylnieja/govydoc/internal/testmodels/moremodels"
 )
 
 func TestGenerate(t *testing.T) {
@@ -37,6 +47,24 @@ func TestGenerate(t *testing.T) {
 	}
 }
 
+func TestGenerateContext(t *testing.T) {
+	validator := govy.New[testmodels.Teacher]().WithName("Teacher")
+
+	t.Run("active context", func(t *testing.T) {
+		doc, err := GenerateContext(t.Context(), validator)
+		require.NoError(t, err)
+		assert.Equal(t, "Teacher", doc.Name)
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		_, err := GenerateContext(ctx, validator)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
+
 func TestPropertyDoc_key(t *testing.T) {
 	t.Parallel()
 
@@ -107,6 +135,17 @@ func TestWithFilteredPaths(t *testing.T) {
 		assert.NotContains(t, paths, "$.name")
 	})
 
+	t.Run("patterns", func(t *testing.T) {
+		doc, err := Generate(validator, WithFilteredPaths("$.**.name", "$.h*"))
+		require.NoError(t, err)
+
+		paths := propertyPaths(doc)
+		assert.NotContains(t, paths, "$.name")
+		assert.NotContains(t, paths, "$.students[*].name")
+		assert.NotContains(t, paths, "$.hobby")
+		assert.Contains(t, paths, "$.students[*].age")
+	})
+
 	t.Run("no paths", func(t *testing.T) {
 		doc, err := Generate(validator)
 		require.NoError(t, err)
@@ -117,6 +156,61 @@ func TestWithFilteredPaths(t *testing.T) {
 	})
 }
 
+func TestWithIncludedPaths(t *testing.T) {
+	validator := govy.New(
+		govy.For(func(t testmodels.Teacher) string { return t.Name }).
+			WithName("name").
+			Rules(rules.EQ("John")),
+		govy.For(func(t testmodels.Teacher) string { return t.Hobby }).
+			WithName("hobby").
+			Rules(rules.EQ("reading")),
+	).
+		WithName("Teacher")
+
+	t.Run("one path", func(t *testing.T) {
+		doc, err := Generate(validator, WithIncludedPaths("$.name"))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"$", "$.name"}, propertyPaths(doc))
+		assert.Equal(t, []string{"$.name"}, findProperty(t, doc, "$").ChildrenPaths)
+		require.NoError(t, doc.Validate())
+	})
+
+	t.Run("nested slice path", func(t *testing.T) {
+		doc, err := Generate(validator, WithIncludedPaths("$.students[*].name"))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"$", "$.students", "$.students[*]", "$.students[*].name"}, propertyPaths(doc))
+		assert.Equal(t, []string{"$.students[*].name"}, findProperty(t, doc, "$.students[*]").ChildrenPaths)
+	})
+
+	t.Run("slice path", func(t *testing.T) {
+		doc, err := Generate(validator, WithIncludedPaths("$.students"))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"$", "$.students", "$.students[*]"}, propertyPaths(doc))
+		assert.Empty(t, findProperty(t, doc, "$.students[*]").ChildrenPaths)
+	})
+
+	t.Run("map path", func(t *testing.T) {
+		doc, err := Generate(govy.New[testmodels.MapStruct](), WithIncludedPaths("$.data"))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"$", "$.data", "$.data.*~", "$.data.*"}, propertyPaths(doc))
+	})
+
+	t.Run("with filtered paths", func(t *testing.T) {
+		doc, err := Generate(
+			validator,
+			WithFilteredPaths("$.hobby"),
+			WithIncludedPaths("$.name", "$.hobby"),
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"$", "$.name"}, propertyPaths(doc))
+	})
+}
+
 func TestGenerateGovyOptions(t *testing.T) {
 	validator := govy.New(
 		govy.For(func(t testmodels.Teacher) string { return t.Name }).
@@ -156,6 +250,34 @@ func TestGenerate_PointerType(t *testing.T) {
 	assert.NotEmpty(t, doc.Properties)
 }
 
+type teacherAlias = testmodels.Teacher
+
+func TestGenerate_TypeAlias(t *testing.T) {
+	tests := map[string]struct {
+		validator govy.Validator[teacherAlias]
+		expected  string
+	}{
+		"validator name": {
+			validator: govy.New[teacherAlias]().WithName("Instructor"),
+			expected:  "Instructor",
+		},
+		"underlying type name": {
+			validator: govy.New[teacherAlias](),
+			expected:  "Teacher",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			doc, err := Generate(test.validator)
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, doc.Name)
+			assert.Contains(t, propertyPaths(doc), "$.name")
+		})
+	}
+}
+
 func TestGenerate_NestedStructs(t *testing.T) {
 	validator := govy.New(
 		govy.For(func(p testmodels.Person) string { return p.Name }).
@@ -173,6 +295,267 @@ func TestGenerate_NestedStructs(t *testing.T) {
 	assert.Contains(t, paths, "$.address.state")
 }
 
+func TestGenerate_EmbeddedStructPointer(t *testing.T) {
+	validator := govy.New(
+		govy.For(func(r testmodels.Resident) string { return r.City }).
+			WithName("city").
+			Rules(rules.EQ("Warsaw")),
+	).
+		WithName("Resident")
+
+	doc, err := Generate(validator)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"$", "$.name", "$.city", "$.state"}, propertyPaths(doc))
+	city := doc.Properties[2]
+	assert.Equal(t, "City is the name of the city.", city.FieldDoc)
+	assert.Len(t, city.Rules, 1)
+}
+
+func TestGenerate_EmbeddedMode(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		opts     []GenerateOption
+		expected []string
+	}{
+		"default": {
+			expected: []string{"$", "$.name", "$.city", "$.state"},
+		},
+		"flatten": {
+			opts:     []GenerateOption{WithEmbeddedMode(EmbeddedModeFlatten)},
+			expected: []string{"$", "$.name", "$.city", "$.state"},
+		},
+		"nest": {
+			opts:     []GenerateOption{WithEmbeddedMode(EmbeddedModeNest)},
+			expected: []string{"$", "$.name", "$.Address", "$.Address.city", "$.Address.state"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			doc, err := Generate(govy.New[testmodels.Resident]().WithName("Resident"), test.opts...)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, propertyPaths(doc))
+		})
+	}
+
+	t.Run("nested docs", func(t *testing.T) {
+		t.Parallel()
+
+		doc, err := Generate(
+			govy.New(
+				govy.For(func(r testmodels.Resident) string { return r.City }).
+					WithName("Address.city").
+					Rules(rules.EQ("Warsaw")),
+			).WithName("Resident"),
+			WithEmbeddedMode(EmbeddedModeNest),
+			WithDeclarationOrder(),
+		)
+		require.NoError(t, err)
+
+		assert.Empty(t, doc.PlanWarnings)
+		assert.Equal(t, []string{"$.name", "$.Address"}, findProperty(t, doc, "$").ChildrenPaths)
+		address := findProperty(t, doc, "$.Address")
+		assert.Equal(t, "Address is where the resident lives.", address.FieldDoc)
+		assert.True(t, address.AllowsNull)
+		assert.Equal(t, PathRoleField, address.PathRole)
+		city := findProperty(t, doc, "$.Address.city")
+		assert.Equal(t, "City is the name of the city.", city.FieldDoc)
+		assert.Len(t, city.Rules, 1)
+	})
+
+	t.Run("tagged embedded struct", func(t *testing.T) {
+		t.Parallel()
+
+		for _, mode := range []EmbeddedMode{EmbeddedModeFlatten, EmbeddedModeNest} {
+			doc, err := Generate(govy.New[testmodels.Tenant]().WithName("Tenant"), WithEmbeddedMode(mode))
+			require.NoError(t, err)
+
+			assert.Equal(t, []string{"$", "$.name", "$.address", "$.address.city", "$.address.state"}, propertyPaths(doc))
+		}
+	})
+}
+
+func TestGenerate_RecursiveTypes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("linked list", func(t *testing.T) {
+		t.Parallel()
+
+		doc, err := Generate(govy.New[testmodels.ListNode]().WithName("ListNode"))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"$", "$.value", "$.next"}, propertyPaths(doc))
+		next := findProperty(t, doc, "$.next")
+		assert.Equal(t, "$", next.RecursiveRef)
+		assert.Equal(t, "Next is the following node, it is nil for the last node.", next.FieldDoc)
+		assert.True(t, next.AllowsNull)
+		assert.Empty(t, next.ChildrenPaths)
+		assert.Empty(t, findProperty(t, doc, "$").RecursiveRef)
+	})
+
+	t.Run("tree", func(t *testing.T) {
+		t.Parallel()
+
+		doc, err := Generate(govy.New[testmodels.TreeNode]().WithName("TreeNode"))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			"$",
+			"$.children",
+			"$.children[*]",
+			"$.named",
+			"$.named.*~",
+			"$.named.*",
+		}, propertyPaths(doc))
+		assert.Equal(t, "$", findProperty(t, doc, "$.children[*]").RecursiveRef)
+		assert.Equal(t, "$", findProperty(t, doc, "$.named.*").RecursiveRef)
+		assert.Empty(t, findProperty(t, doc, "$.named.*~").RecursiveRef)
+	})
+}
+
+func TestGenerate_EnumValues(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range []DocFormat{DocMarkdown, DocHTML, DocPlain} {
+		t.Run(string(format), func(t *testing.T) {
+			t.Parallel()
+
+			doc, err := Generate(govy.New[testmodels.Shape]().WithName("Shape"), WithDocFormat(format))
+			require.NoError(t, err)
+
+			color := findProperty(t, doc, "$.color")
+			assert.Equal(t, []string{"Red", "Green", "Blue"}, color.EnumValues)
+			assert.NotContains(t, color.TypeDoc, "ENUM")
+			assert.Contains(t, color.TypeDoc, "Color is the fill color of a")
+			size := findProperty(t, doc, "$.size")
+			assert.Equal(t, []string{"small", "medium", "large"}, size.EnumValues)
+			assert.NotContains(t, size.TypeDoc, "ENUM")
+			assert.Nil(t, findProperty(t, doc, "$").EnumValues)
+		})
+	}
+}
+
+func TestGenerate_EnumConstants(t *testing.T) {
+	t.Parallel()
+
+	doc, err := Generate(govy.New[testmodels.Theme]().WithName("Theme"))
+	require.NoError(t, err)
+
+	mode := findProperty(t, doc, "$.mode")
+	assert.Equal(t, []EnumConstant{
+		{Name: "ThemeModeLight", Value: "light", Doc: "ThemeModeLight uses dark text on a light background."},
+		{Name: "ThemeModeDark", Value: "dark", Doc: "ThemeModeDark uses light text on a dark background."},
+	}, mode.EnumConstants)
+	assert.Equal(t, []string{"light", "dark"}, mode.AllowedValues)
+	contrast := findProperty(t, doc, "$.contrast")
+	assert.Equal(t, []EnumConstant{
+		{Name: "ContrastNormal", Value: "0"},
+		{Name: "ContrastHigh", Value: "1"},
+	}, contrast.EnumConstants)
+	assert.Equal(t, []string{"0", "1"}, contrast.AllowedValues)
+	assert.Nil(t, findProperty(t, doc, "$").EnumConstants)
+
+	t.Run("restricted by rules", func(t *testing.T) {
+		t.Parallel()
+
+		validator := govy.New(
+			govy.For(func(t testmodels.Theme) testmodels.ThemeMode { return t.Mode }).
+				WithName("mode").
+				Rules(rules.OneOf(testmodels.ThemeModeDark)),
+		).WithName("Theme")
+		doc, err := Generate(validator)
+		require.NoError(t, err)
+
+		mode := findProperty(t, doc, "$.mode")
+		assert.Len(t, mode.EnumConstants, 2)
+		assert.Equal(t, []string{"dark"}, mode.AllowedValues)
+	})
+}
+
+func TestGenerate_AllowedValues(t *testing.T) {
+	t.Parallel()
+
+	validator := govy.New(
+		govy.For(func(s testmodels.Shape) testmodels.Size { return s.Size }).
+			WithName("size").
+			Rules(rules.OneOf[testmodels.Size]("small", "medium")),
+	).WithName("Shape")
+
+	doc, err := Generate(validator)
+	require.NoError(t, err)
+
+	size := findProperty(t, doc, "$.size")
+	assert.Equal(t, []string{"small", "medium", "large"}, size.EnumValues)
+	assert.Equal(t, []string{"small", "medium"}, size.AllowedValues)
+	assert.Equal(t, []string{"Red", "Green", "Blue"}, findProperty(t, doc, "$.color").AllowedValues)
+	assert.Nil(t, findProperty(t, doc, "$").AllowedValues)
+}
+
+func TestGenerate_HiddenFields(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		opts     []GenerateOption
+		expected []string
+		hidden   []string
+	}{
+		"default": {
+			expected: []string{"$", "$.user"},
+		},
+		"ignored fields": {
+			opts:     []GenerateOption{WithIncludeHidden()},
+			expected: []string{"$", "$.user", "$.Password", "$.Session", "$.Session.id"},
+			hidden:   []string{"$.Password", "$.Session", "$.Session.id"},
+		},
+		"unexported fields": {
+			opts:     []GenerateOption{WithIncludeUnexported()},
+			expected: []string{"$", "$.user", "$.token"},
+			hidden:   []string{"$.token"},
+		},
+		"all": {
+			opts:     []GenerateOption{WithIncludeHidden(), WithIncludeUnexported()},
+			expected: []string{"$", "$.user", "$.Password", "$.Session", "$.Session.id", "$.token"},
+			hidden:   []string{"$.Password", "$.Session", "$.Session.id", "$.token"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			doc, err := Generate(govy.New[testmodels.Credentials]().WithName("Credentials"), test.opts...)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, propertyPaths(doc))
+			var hidden []string
+			for _, property := range doc.Properties {
+				if property.Hidden {
+					hidden = append(hidden, property.Path.String())
+				}
+			}
+			assert.Equal(t, test.hidden, hidden)
+		})
+	}
+
+	t.Run("field docs", func(t *testing.T) {
+		t.Parallel()
+
+		doc, err := Generate(
+			govy.New[testmodels.Credentials]().WithName("Credentials"),
+			WithIncludeHidden(),
+			WithIncludeUnexported(),
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, "Password is never encoded.", findProperty(t, doc, "$.Password").FieldDoc)
+		assert.Equal(t, "Session is the client's current session.", findProperty(t, doc, "$.Session").FieldDoc)
+		assert.Equal(t, "token is the cached access token.", findProperty(t, doc, "$.token").FieldDoc)
+	})
+}
+
 func TestGenerate_SliceTypes(t *testing.T) {
 	validator := govy.New[testmodels.ListStruct]().WithName("ListStruct")
 
@@ -183,6 +566,26 @@ func TestGenerate_SliceTypes(t *testing.T) {
 	assert.Contains(t, propertyPaths(doc), "$.items[*]")
 }
 
+func TestGenerate_ArrayTypes(t *testing.T) {
+	t.Run("array field", func(t *testing.T) {
+		doc, err := Generate(govy.New[testmodels.Route]().WithName("Route"))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"$", "$.stops", "$.stops[*]", "$.stops[*].city", "$.stops[*].state"}, propertyPaths(doc))
+		assert.Equal(t, []string{"$.stops[*]"}, findProperty(t, doc, "$.stops").ChildrenPaths)
+		assert.Equal(t, PathRoleSliceItem, findProperty(t, doc, "$.stops[*]").PathRole)
+		assert.Equal(t, "City is the name of the city.", findProperty(t, doc, "$.stops[*].city").FieldDoc)
+	})
+
+	t.Run("root array", func(t *testing.T) {
+		doc, err := Generate(govy.New[[2]testmodels.Teacher]().WithName("Teachers"))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"$[*]"}, findProperty(t, doc, "$").ChildrenPaths)
+		assert.Equal(t, "Name is the name of the teacher.", findProperty(t, doc, "$[*].name").FieldDoc)
+	})
+}
+
 func TestGenerate_MapTypes(t *testing.T) {
 	validator := govy.New[testmodels.MapStruct]().WithName("MapStruct")
 
@@ -195,20 +598,1846 @@ func TestGenerate_MapTypes(t *testing.T) {
 	assert.Contains(t, paths, "$.data.*")
 }
 
-//go:embed testdata/generate_output.json
-var expectedGenerateOutput []byte
-
-func mustMarshalJSON(t *testing.T, value any) string {
-	t.Helper()
-	data, err := json.Marshal(value)
+func TestGenerate_PathRoles(t *testing.T) {
+	doc, err := Generate(govy.New[testmodels.Team]())
 	require.NoError(t, err)
-	return string(data)
+
+	roles := make(map[string]PathRole, len(doc.Properties))
+	for _, property := range doc.Properties {
+		roles[property.Path.String()] = property.PathRole
+	}
+	assert.Equal(t, PathRoleRoot, roles["$"])
+	assert.Equal(t, PathRoleField, roles["$.members"])
+	assert.Equal(t, PathRoleSliceItem, roles["$.members[*]"])
+	assert.Equal(t, PathRoleField, roles["$.labels"])
+	assert.Equal(t, PathRoleMapKey, roles["$.labels.*~"])
+	assert.Equal(t, PathRoleMapValue, roles["$.labels.*"])
+	assert.Equal(t, PathRoleField, roles["$.lead.address.city"])
+	assert.Contains(t, mustMarshalJSON(t, doc), `"pathRole":"mapKey"`)
 }
 
-func propertyPaths(doc ObjectDoc) []string {
-	paths := make([]string, 0, len(doc.Properties))
+func TestWithUnionGroups(t *testing.T) {
+	validator := govy.New(
+		govy.For(govy.GetSelf[testmodels.Payment]()).
+			Rules(rules.MutuallyExclusive(true, map[string]func(p testmodels.Payment) any{
+				"card":     func(p testmodels.Payment) any { return p.Card },
+				"transfer": func(p testmodels.Payment) any { return p.Transfer },
+			})),
+	).
+		WithName("Payment")
+
+	t.Run("enabled", func(t *testing.T) {
+		doc, err := Generate(validator, WithUnionGroups())
+		require.NoError(t, err)
+
+		assert.Equal(t, []UnionGroup{{
+			Path:       "$",
+			Properties: []string{"$.card", "$.transfer"},
+		}}, doc.UnionGroups)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		doc, err := Generate(validator)
+		require.NoError(t, err)
+
+		assert.Empty(t, doc.UnionGroups)
+	})
+
+	t.Run("filtered member", func(t *testing.T) {
+		doc, err := Generate(validator, WithUnionGroups(), WithFilteredPaths("$.transfer"))
+		require.NoError(t, err)
+
+		assert.Empty(t, doc.UnionGroups)
+	})
+
+	t.Run("filtered rule", func(t *testing.T) {
+		doc, err := Generate(validator, WithUnionGroups(), WithFilteredRules(rules.ErrorCodeMutuallyExclusive))
+		require.NoError(t, err)
+
+		assert.Empty(t, doc.UnionGroups)
+	})
+}
+
+func TestWithoutMapKeys(t *testing.T) {
+	validator := govy.New[testmodels.MapStruct]().WithName("MapStruct")
+
+	doc, err := Generate(validator, WithoutMapKeys())
+
+	require.NoError(t, err)
+	paths := propertyPaths(doc)
+	assert.NotContains(t, paths, "$.data.*~")
+	assert.Contains(t, paths, "$.data.*")
 	for _, property := range doc.Properties {
-		paths = append(paths, property.Path.String())
+		assert.NotContains(t, property.ChildrenPaths, "$.data.*~")
 	}
-	return paths
+}
+
+func TestWithRawDocs(t *testing.T) {
+	validator := govy.New[testmodels.Teacher]().WithName("Teacher")
+
+	t.Run("enabled", func(t *testing.T) {
+		doc, err := Generate(validator, WithRawDocs())
+		require.NoError(t, err)
+
+		student := findProperty(t, doc, "$.students[*]")
+		assert.Contains(t, student.RawTypeDoc, "Have you seen [Teacher]?")
+		assert.Contains(t, student.RawTypeDoc, "[this site]: https://example.com")
+		assert.Contains(t, student.TypeDoc,
+			"Have you seen [Teacher](https://pkg.go.dev/github.com/nieomylnieja/govydoc/internal/testmodels#Teacher)?")
+		assert.NotContains(t, student.TypeDoc, "[this site]: https://example.com")
+		name := findProperty(t, doc, "$.students[*].name")
+		assert.Equal(t, "Some comment.", name.RawFieldDoc)
+		assert.Equal(t, "Some comment.", name.FieldDoc)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		doc, err := Generate(validator)
+		require.NoError(t, err)
+
+		student := findProperty(t, doc, "$.students[*]")
+		assert.Empty(t, student.RawTypeDoc)
+		assert.Empty(t, student.RawFieldDoc)
+	})
+}
+
+func TestWithArrayToken(t *testing.T) {
+	validator := govy.New[testmodels.ListStruct]().WithName("ListStruct")
+
+	t.Run("empty brackets", func(t *testing.T) {
+		doc, err := Generate(validator, WithArrayToken("[]"))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"$", "$.items", "$.items[]"}, propertyPaths(doc))
+		assert.Equal(t, []string{"$.items"}, doc.Properties[0].ChildrenPaths)
+		assert.Equal(t, []string{"$.items[]"}, doc.Properties[1].ChildrenPaths)
+	})
+
+	t.Run("index placeholder", func(t *testing.T) {
+		_, err := Generate(validator, WithArrayToken("[i]"))
+		require.EqualError(t, err, `invalid array token "[i]": path $.items[i].name is parsed as $.items.i.name`)
+	})
+
+	t.Run("no brackets", func(t *testing.T) {
+		_, err := Generate(validator, WithArrayToken("*"))
+		require.EqualError(t, err, `invalid array token "*": token must be enclosed in square brackets`)
+	})
+}
+
+func TestGenerate_Validate(t *testing.T) {
+	validator := govy.New[testmodels.Person]().WithName("Person")
+
+	t.Run("valid", func(t *testing.T) {
+		doc, err := Generate(validator)
+		require.NoError(t, err)
+
+		assert.NoError(t, doc.Validate())
+	})
+
+	t.Run("filtered parent", func(t *testing.T) {
+		doc, err := Generate(validator, WithFilteredPaths("$.address"))
+		require.NoError(t, err)
+
+		err = doc.Validate()
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "property $.address.city is not listed among the children of any property")
+		assert.ErrorContains(t, err, "property $.address.state is not listed among the children of any property")
+	})
+
+	t.Run("omitted parents", func(t *testing.T) {
+		for name, opt := range map[string]GenerateOption{
+			"type kind filter": WithTypeKindFilter("struct", "string"),
+			"included paths":   WithIncludedPaths("$.students[*].name"),
+		} {
+			doc, err := Generate(govy.New[testmodels.Teacher](), opt)
+			require.NoError(t, err)
+
+			assert.NoError(t, doc.Validate(), name)
+		}
+	})
+}
+
+func TestGenerate_MapRootType(t *testing.T) {
+	addressValidator := govy.New(
+		govy.For(func(a testmodels.Address) string { return a.City }).
+			WithName("city").
+			Rules(rules.EQ("Warsaw")),
+	)
+	validator := govy.New(
+		govy.ForMap(govy.GetSelf[map[string]testmodels.Address]()).
+			IncludeForValues(addressValidator),
+	).
+		WithName("Addresses")
+
+	doc, err := Generate(validator)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"$", "$.*~", "$.*", "$.*.city", "$.*.state"}, propertyPaths(doc))
+	value := findProperty(t, doc, "$.*")
+	assert.Equal(t, "Address represents a physical address.", value.TypeDoc)
+	city := findProperty(t, doc, "$.*.city")
+	assert.Equal(t, "City is the name of the city.", city.FieldDoc)
+	require.Len(t, city.Rules, 1)
+	assert.Equal(t, "must be equal to 'Warsaw'", city.Rules[0].Description)
+}
+
+func TestWithDocBlocks(t *testing.T) {
+	validator := govy.New[testmodels.Shipment]().WithName("Shipment")
+
+	t.Run("enabled", func(t *testing.T) {
+		doc, err := Generate(validator, WithDocBlocks())
+		require.NoError(t, err)
+
+		assert.Equal(t, []DocBlock{
+			{Kind: DocBlockParagraph, Text: "Shipment describes a package delivery."},
+			{Kind: DocBlockHeading, Text: "Carriers"},
+			{Kind: DocBlockParagraph, Text: "Supported carriers:"},
+			{Kind: DocBlockList, Items: []string{"Postal service", "Courier company"}},
+			{Kind: DocBlockParagraph, Text: "Example tracking number:"},
+			{Kind: DocBlockCode, Text: "AB123456789\n"},
+		}, findProperty(t, doc, "$").TypeDocBlocks)
+		assert.Equal(t, []DocBlock{
+			{Kind: DocBlockParagraph, Text: "Priority is one of:"},
+			{Kind: DocBlockList, Items: []string{"standard", "express"}, Ordered: true},
+		}, findProperty(t, doc, "$.priority").FieldDocBlocks)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		doc, err := Generate(validator)
+		require.NoError(t, err)
+
+		assert.Empty(t, findProperty(t, doc, "$").TypeDocBlocks)
+		assert.Empty(t, findProperty(t, doc, "$.priority").FieldDocBlocks)
+	})
+}
+
+func TestGenerate_CompositeRootType(t *testing.T) {
+	validator := govy.New[[]map[string]testmodels.Address]().WithName("Addresses")
+
+	doc, err := Generate(validator)
+
+	require.NoError(t, err)
+	assert.Equal(t,
+		[]string{"$", "$[*]", "$[*].*~", "$[*].*", "$[*].*.city", "$[*].*.state"},
+		propertyPaths(doc))
+	root := findProperty(t, doc, "$")
+	assert.Equal(t, "[]map[string]testmodels.Address", root.TypeInfo.Name)
+	assert.Equal(t, "[]map[string]struct", root.TypeInfo.Kind)
+	assert.Equal(t, []string{"$[*]"}, root.ChildrenPaths)
+	assert.Equal(t, []string{"$[*].*~", "$[*].*"}, findProperty(t, doc, "$[*]").ChildrenPaths)
+	value := findProperty(t, doc, "$[*].*")
+	assert.Equal(t, "Address represents a physical address.", value.TypeDoc)
+	assert.Equal(t, []string{"$[*].*.city", "$[*].*.state"}, value.ChildrenPaths)
+}
+
+func TestWithStableIDs(t *testing.T) {
+	validator := govy.New[testmodels.Account]().WithName("Account")
+
+	t.Run("custom tag", func(t *testing.T) {
+		doc, err := Generate(validator, WithStableIDs(func(p PropertyDoc) string {
+			return p.StructTag.Get("id")
+		}))
+		require.NoError(t, err)
+
+		assert.Equal(t, "$", findProperty(t, doc, "$").ID)
+		assert.Equal(t, "1", findProperty(t, doc, "$.login").ID)
+		assert.Equal(t, "2", findProperty(t, doc, "$.email").ID)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		doc, err := Generate(validator)
+		require.NoError(t, err)
+
+		assert.Empty(t, findProperty(t, doc, "$.login").ID)
+	})
+}
+
+func TestWithNameMapping(t *testing.T) {
+	validator := govy.New(
+		govy.For(func(p testmodels.Person) string { return p.Name }).
+			WithName("fullName").
+			Rules(rules.EQ("John")),
+		govy.For(func(p testmodels.Person) testmodels.Address { return p.Address }).
+			WithName("location").
+			Include(govy.New(
+				govy.For(func(a testmodels.Address) string { return a.City }).
+					WithName("city").
+					Rules(rules.EQ("Warsaw")),
+			)),
+	).
+		WithName("Person")
+
+	t.Run("mapped", func(t *testing.T) {
+		doc, err := Generate(validator, WithNameMapping(map[string]string{
+			"$.fullName": "$.name",
+			"$.location": "$.address",
+		}))
+		require.NoError(t, err)
+
+		name := findProperty(t, doc, "$.name")
+		require.Len(t, name.Rules, 1)
+		assert.Equal(t, "must be equal to 'John'", name.Rules[0].Description)
+		assert.Equal(t, "fullName", name.ValidatorName)
+		city := findProperty(t, doc, "$.address.city")
+		require.Len(t, city.Rules, 1)
+		assert.Equal(t, "must be equal to 'Warsaw'", city.Rules[0].Description)
+		assert.Equal(t, "City is the name of the city.", city.FieldDoc)
+		assert.Empty(t, city.ValidatorName)
+	})
+
+	t.Run("not mapped", func(t *testing.T) {
+		doc, err := Generate(validator)
+		require.NoError(t, err)
+
+		assert.Empty(t, findProperty(t, doc, "$.name").Rules)
+		assert.Empty(t, findProperty(t, doc, "$.address.city").Rules)
+	})
+}
+
+func TestWithExportedTypesOnly(t *testing.T) {
+	validator := govy.New[testmodels.Library]().WithName("Library")
+
+	t.Run("enabled", func(t *testing.T) {
+		doc, err := Generate(validator, WithExportedTypesOnly())
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"$", "$.name", "$.shelf", "$.shelves", "$.shelves[*]"}, propertyPaths(doc))
+		shelf := findProperty(t, doc, "$.shelf")
+		assert.Equal(t, "shelf", shelf.TypeInfo.Name)
+		assert.Empty(t, shelf.ChildrenPaths)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		doc, err := Generate(validator)
+		require.NoError(t, err)
+
+		paths := propertyPaths(doc)
+		assert.Contains(t, paths, "$.shelf.position")
+		assert.Contains(t, paths, "$.shelves[*].position")
+	})
+}
+
+func TestWithMaxDepth(t *testing.T) {
+	validator := govy.New(
+		govy.ForSlice(func(t testmodels.Teacher) []testmodels.Student { return t.Students }).
+			WithName("students").
+			IncludeForEach(govy.New(
+				govy.For(func(s testmodels.Student) string { return s.Name }).
+					WithName("name").
+					Required(),
+			)),
+	).WithName("Teacher")
+
+	t.Run("root fields only", func(t *testing.T) {
+		doc, err := Generate(validator, WithMaxDepth(0))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			"$", "$.name", "$.hobby", "$.age", "$.students", "$.students[*]", "$.university", "$.stringer",
+		}, propertyPaths(doc))
+		assert.Equal(t, 1, doc.MaxDepth())
+		students := findProperty(t, doc, "$.students[*]")
+		assert.True(t, students.Truncated)
+		assert.Empty(t, students.ChildrenPaths)
+		assert.False(t, findProperty(t, doc, "$.students").Truncated)
+		assert.False(t, findProperty(t, doc, "$.university").Truncated, "University has no documented fields")
+		assert.Empty(t, doc.PlanWarnings)
+	})
+
+	t.Run("deeper than the object", func(t *testing.T) {
+		doc, err := Generate(validator, WithMaxDepth(1))
+		require.NoError(t, err)
+
+		fullDoc, err := Generate(validator)
+		require.NoError(t, err)
+		assert.Equal(t, propertyPaths(fullDoc), propertyPaths(doc))
+		for _, property := range doc.Properties {
+			assert.False(t, property.Truncated, property.Path.String())
+		}
+		assert.True(t, findProperty(t, doc, "$.students[*].name").Required)
+	})
+
+	t.Run("negative depth", func(t *testing.T) {
+		_, err := Generate(validator, WithMaxDepth(-1))
+		require.EqualError(t, err, "invalid max depth -1: depth must not be negative")
+	})
+}
+
+func TestWithMetadata(t *testing.T) {
+	validator := govy.New[testmodels.Address]().WithName("Address")
+
+	doc, err := Generate(
+		validator,
+		WithMetadata(map[string]string{"owner": "platform", "tier": "1"}),
+		WithMetadata(map[string]string{"tier": "2"}),
+	)
+	require.NoError(t, err)
+
+	var decoded ObjectDoc
+	require.NoError(t, json.Unmarshal([]byte(mustMarshalJSON(t, doc)), &decoded))
+	assert.Equal(t, map[string]string{"owner": "platform", "tier": "2"}, decoded.Metadata)
+
+	doc, err = Generate(validator)
+	require.NoError(t, err)
+	assert.NotContains(t, mustMarshalJSON(t, doc), `"metadata"`)
+}
+
+func TestWithKind(t *testing.T) {
+	validator := govy.New[testmodels.Address]().WithName("Address")
+
+	doc, err := Generate(validator, WithKind("request"))
+	require.NoError(t, err)
+
+	var decoded ObjectDoc
+	require.NoError(t, json.Unmarshal([]byte(mustMarshalJSON(t, doc)), &decoded))
+	assert.Equal(t, "request", decoded.Kind)
+	assert.Equal(t, "Address", decoded.Name)
+
+	doc, err = Generate(validator)
+	require.NoError(t, err)
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal([]byte(mustMarshalJSON(t, doc)), &fields))
+	assert.NotContains(t, fields, "kind")
+}
+
+func TestWithDocFormat(t *testing.T) {
+	validator := govy.New[testmodels.Teacher]().WithName("Teacher")
+
+	t.Run("html", func(t *testing.T) {
+		doc, err := Generate(validator, WithDocFormat(DocHTML))
+		require.NoError(t, err)
+
+		root := findProperty(t, doc, "$")
+		assert.Contains(t, root.TypeDoc, "<p>Teacher is a sample struct used for testing.")
+		assert.Contains(
+			t,
+			root.TypeDoc,
+			`<a href="https://pkg.go.dev/github.com/nieomylnieja/govydoc/internal/testmodels#Student">`,
+		)
+		assert.Equal(t, "<p>Name is the name of the teacher.", findProperty(t, doc, "$.name").FieldDoc)
+		oldName := findProperty(t, doc, "$.students[*].oldName")
+		assert.Equal(t, "Use Name instead.", oldName.DeprecatedDoc)
+		assert.Empty(t, oldName.FieldDoc)
+	})
+
+	t.Run("plain", func(t *testing.T) {
+		doc, err := Generate(validator, WithDocFormat(DocPlain))
+		require.NoError(t, err)
+
+		root := findProperty(t, doc, "$")
+		assert.NotContains(t, root.TypeDoc, "<p>")
+		assert.NotContains(t, root.TypeDoc, "](https://pkg.go.dev")
+		assert.Equal(t, "Name is the name of the teacher.", findProperty(t, doc, "$.name").FieldDoc)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		_, err := Generate(validator, WithDocFormat("rst"))
+		require.EqualError(t, err, `unsupported doc format "rst"`)
+	})
+}
+
+func TestWithDocLinkBaseURL(t *testing.T) {
+	validator := govy.New[testmodels.Teacher]().WithName("Teacher")
+
+	for _, baseURL := range []string{"https://godoc.example.com", "https://godoc.example.com/"} {
+		t.Run(baseURL, func(t *testing.T) {
+			doc, err := Generate(validator, WithDocLinkBaseURL(baseURL))
+			require.NoError(t, err)
+
+			root := findProperty(t, doc, "$")
+			assert.Contains(t, root.TypeDoc,
+				"[Student](https://godoc.example.com/github.com/nieomylnieja/govydoc/internal/testmodels#Student)")
+			assert.NotContains(t, root.TypeDoc, "https://pkg.go.dev")
+			assert.Contains(t, doc.PackageDoc, "https://godoc.example.com/")
+			assert.Contains(t, findProperty(t, doc, "$.students[*]").TypeDoc,
+				"[fmt.Stringer](https://godoc.example.com/fmt#Stringer)")
+		})
+	}
+
+	t.Run("relative URL", func(t *testing.T) {
+		_, err := Generate(validator, WithDocLinkBaseURL("/docs"))
+		require.EqualError(t, err, `invalid doc link base URL "/docs": URL must be absolute`)
+	})
+}
+
+func TestGenerate_Plan(t *testing.T) {
+	validator := govy.New(
+		govy.For(func(t testmodels.Teacher) string { return t.Name }).
+			WithName("fullName").
+			Rules(rules.StringNotEmpty(), rules.StringMaxLength(10)),
+		govy.For(func(t testmodels.Teacher) int { return t.Age }).
+			WithName("years").
+			Rules(rules.GT(0)),
+	).WithName("Teacher")
+
+	doc, err := Generate(
+		validator,
+		WithNameMapping(map[string]string{"$.fullName": "$.name"}),
+		WithFilteredRules(rules.ErrorCodeStringMaxLength),
+	)
+	require.NoError(t, err)
+
+	expected, err := govy.Plan(validator)
+	require.NoError(t, err)
+	assert.Equal(t, expected, doc.Plan)
+	assert.Len(t, findProperty(t, doc, "$.name").Rules, 1)
+
+	doc.Plan.Properties[0].Rules[0].Description = "changed"
+	assert.NotEqual(t, "changed", findProperty(t, doc, "$.name").Rules[0].Description)
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), `"plan"`)
+}
+
+func TestGenerateType(t *testing.T) {
+	doc, err := GenerateType[testmodels.Teacher](WithExamples(Example{Name: "teacher", Content: `{"name":"John"}`}))
+	require.NoError(t, err)
+
+	expected, err := Generate(govy.New[testmodels.Teacher]())
+	require.NoError(t, err)
+	assert.Equal(t, propertyPaths(expected), propertyPaths(doc))
+	assert.Nil(t, doc.Plan)
+	assert.Empty(t, doc.PlanWarnings)
+	name := findProperty(t, doc, "$.name")
+	assert.Empty(t, name.Rules)
+	assert.Empty(t, name.RuleDocs)
+	assert.Equal(t, "Name is the name of the teacher.", name.FieldDoc)
+	assert.Contains(t, findProperty(t, doc, "$").TypeDoc, "Teacher is a sample struct used for testing.")
+	assert.Equal(t, []Example{{Name: "teacher", Content: `{"name":"John"}`}}, doc.Examples)
+
+	_, err = GenerateType[string]()
+	require.ErrorContains(t, err, "cannot document string")
+}
+
+func TestGenerate_PlanWarnings(t *testing.T) {
+	t.Run("clean validator", func(t *testing.T) {
+		doc, err := Generate(govy.New(
+			govy.For(func(p testmodels.Person) string { return p.Name }).
+				WithName("name").
+				Rules(rules.StringNotEmpty()),
+		).WithName("Person"))
+
+		require.NoError(t, err)
+		assert.Empty(t, doc.PlanWarnings)
+	})
+
+	t.Run("unmatched property", func(t *testing.T) {
+		doc, err := Generate(govy.New(
+			govy.For(func(p testmodels.Person) string { return p.Name }).
+				WithName("fullName").
+				Rules(rules.StringNotEmpty()),
+		).WithName("Person"))
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"validation plan property $.fullName does not match any documented property, its 1 rule(s) are not documented",
+		}, doc.PlanWarnings)
+	})
+}
+
+func TestWithDocumenterInterface(t *testing.T) {
+	validator := govy.New[testmodels.Price]().WithName("Price")
+
+	t.Run("enabled", func(t *testing.T) {
+		doc, err := Generate(validator, WithDocumenterInterface())
+		require.NoError(t, err)
+
+		assert.Equal(t, "Currency is an ISO 4217 currency code.", findProperty(t, doc, "$.currency").TypeDoc)
+		assert.Contains(t, findProperty(t, doc, "$.discount").TypeDoc, "Discount is a percentage reduction")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		doc, err := Generate(validator)
+		require.NoError(t, err)
+
+		assert.Empty(t, findProperty(t, doc, "$.currency").TypeDoc)
+	})
+
+	t.Run("interface embedding Documenter", func(t *testing.T) {
+		doc, err := Generate(govy.New[testmodels.Catalog]().WithName("Catalog"), WithDocumenterInterface())
+		require.NoError(t, err)
+
+		item := findProperty(t, doc, "$.item")
+		assert.True(t, item.IsInterface)
+		assert.Contains(t, item.TypeDoc, "Described is implemented by items which describe themselves.")
+		assert.Equal(t, "Currency is an ISO 4217 currency code.", findProperty(t, doc, "$.price.currency").TypeDoc)
+	})
+}
+
+func TestWithTypeDocOverride(t *testing.T) {
+	const testmodelsPkg = "github.com/nieomylnieja/govydoc/internal/testmodels"
+
+	t.Run("overrides doc comments", func(t *testing.T) {
+		doc, err := Generate(
+			govy.New[testmodels.Measurement]().WithName("Measurement"),
+			WithTypeDocOverride(testmodelsPkg, "Sensor", "Sensor is a measuring device."),
+			WithTypeDocOverride("encoding/json", "Number", "Number is a decimal number."),
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, "Sensor is a measuring device.", findProperty(t, doc, "$.sensor").TypeDoc)
+		assert.Equal(t, "Number is a decimal number.", findProperty(t, doc, "$.value").TypeDoc)
+		assert.Equal(t, "Number is a decimal number.", findProperty(t, doc, "$.history[*]").TypeDoc)
+		assert.Equal(t, "Value is the measured value.", findProperty(t, doc, "$.value").FieldDoc)
+	})
+
+	t.Run("overrides documenter interface", func(t *testing.T) {
+		doc, err := Generate(
+			govy.New[testmodels.Price]().WithName("Price"),
+			WithDocumenterInterface(),
+			WithTypeDocOverride(testmodelsPkg, "Currency", "Currency code.\n\nENUM(USD, EUR)"),
+		)
+		require.NoError(t, err)
+
+		currency := findProperty(t, doc, "$.currency")
+		assert.Equal(t, "Currency code.", currency.TypeDoc)
+		assert.Equal(t, []string{"USD", "EUR"}, currency.EnumValues)
+	})
+
+	t.Run("built-in type", func(t *testing.T) {
+		doc, err := Generate(
+			govy.New[testmodels.Price]().WithName("Price"),
+			WithTypeDocOverride("", "int", "Amount in cents."),
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, "Amount in cents.", findProperty(t, doc, "$.amount").TypeDoc)
+		assert.Empty(t, findProperty(t, doc, "$.currency").TypeDoc)
+	})
+}
+
+func TestWithDeclarationOrder(t *testing.T) {
+	doc, err := Generate(
+		govy.New[testmodels.Teacher]().WithName("Teacher"),
+		WithDeclarationOrder(),
+		WithFilteredPaths("$.hobby"),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"$.name",
+		"$.hobby",
+		"$.age",
+		"$.students",
+		"$.university",
+		"$.stringer",
+	}, findProperty(t, doc, "$").ChildrenPaths)
+}
+
+func TestGenerate_Nullability(t *testing.T) {
+	validator := govy.New(
+		govy.For(func(t testmodels.Team) []string { return t.Members }).
+			WithName("members").
+			Required().
+			Rules(rules.SliceMinLength[[]string](1)),
+		govy.For(func(t testmodels.Team) []string { return t.Tags }).
+			WithName("tags").
+			OmitEmpty().
+			Rules(rules.SliceMaxLength[[]string](5)),
+		govy.ForPointer(func(t testmodels.Team) *testmodels.Person { return t.Lead }).
+			WithName("lead").
+			Required(),
+	).WithName("Team")
+
+	doc, err := Generate(validator)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		allowsNull  bool
+		allowsEmpty bool
+	}{
+		"$.members":      {},
+		"$.tags":         {allowsNull: true, allowsEmpty: true},
+		"$.labels":       {allowsNull: true, allowsEmpty: true},
+		"$.lead":         {},
+		"$.lead.name":    {},
+		"$.members[*]":   {},
+		"$.labels.*":     {},
+		"$.lead.address": {},
+	}
+	for path, test := range tests {
+		property := findProperty(t, doc, path)
+		assert.Equal(t, test.allowsNull, property.AllowsNull, "AllowsNull of %s", path)
+		assert.Equal(t, test.allowsEmpty, property.AllowsEmpty, "AllowsEmpty of %s", path)
+	}
+}
+
+func TestGenerate_Required(t *testing.T) {
+	validator := govy.New(
+		govy.For(func(a testmodels.Address) string { return a.City }).
+			WithName("city").
+			Required(),
+		govy.For(func(a testmodels.Address) string { return a.State }).
+			WithName("state").
+			When(func(a testmodels.Address) bool { return a.City != "" }, govy.WhenDescription("when city is set")).
+			Required(),
+	).WithName("Address")
+
+	doc, err := Generate(validator)
+	require.NoError(t, err)
+
+	assert.False(t, findProperty(t, doc, "$").Required)
+	assert.True(t, findProperty(t, doc, "$.city").Required)
+	assert.False(t, findProperty(t, doc, "$.state").Required, "conditionally required property")
+	assert.Contains(t, mustMarshalJSON(t, doc), `"required":true`)
+
+	t.Run("filtered rules", func(t *testing.T) {
+		for name, opt := range map[string]GenerateOption{
+			"WithFilteredRules": WithFilteredRules(rules.ErrorCodeRequired),
+			"WithRuleFilter":    WithRuleFilter(func(rule RuleDoc) bool { return rule.Name == "required" }),
+		} {
+			t.Run(name, func(t *testing.T) {
+				doc, err := Generate(validator, opt)
+				require.NoError(t, err)
+
+				city := findProperty(t, doc, "$.city")
+				assert.Empty(t, city.Rules)
+				assert.False(t, city.Required)
+			})
+		}
+	})
+}
+
+func TestWithExamples(t *testing.T) {
+	validator := govy.New(
+		govy.For(func(t testmodels.Teacher) string { return t.Name }).
+			WithName("name").
+			Rules(rules.EQ("John")),
+		govy.For(func(t testmodels.Teacher) int { return t.Age }).
+			WithName("age").
+			Rules(rules.GT(18)),
+	).WithName("Teacher")
+
+	doc, err := Generate(validator, WithExamples(
+		Example{Name: "valid", Content: `{"name": "John", "age": 30}`},
+		Example{Name: "invalid", Content: `{"name": "Jane", "age": 18}`},
+		Example{Name: "malformed", Content: `{"name": 1}`},
+	))
+	require.NoError(t, err)
+
+	require.Len(t, doc.Examples, 3)
+	valid, invalid, malformed := doc.Examples[0], doc.Examples[1], doc.Examples[2]
+	require.NotNil(t, valid.Valid)
+	assert.True(t, *valid.Valid)
+	assert.Empty(t, valid.Errors)
+	require.NotNil(t, invalid.Valid)
+	assert.False(t, *invalid.Valid)
+	assert.Equal(t, []string{
+		"$.name: must be equal to 'John'",
+		"$.age: must be greater than '18'",
+	}, invalid.Errors)
+	require.NotNil(t, malformed.Valid)
+	assert.False(t, *malformed.Valid)
+	require.Len(t, malformed.Errors, 1)
+	assert.Contains(t, malformed.Errors[0], "failed to decode example")
+}
+
+func TestWithGoExamples(t *testing.T) {
+	validator := govy.New[testmodels.Teacher]().WithName("Teacher")
+
+	doc, err := Generate(validator, WithGoExamples())
+	require.NoError(t, err)
+	assert.Equal(t, []Example{
+		{
+			Name: "ExampleTeacher",
+			Content: "teacher := testmodels.Teacher{Name: \"John\"}\n" +
+				"// Teachers are introduced by their names.\n" +
+				"fmt.Println(teacher.Name)\n" +
+				"// Output: John",
+		},
+		{
+			Name: "ExampleTeacher_students",
+			Content: "teacher := testmodels.Teacher{Students: []testmodels.Student{{}, {}}}\n" +
+				"fmt.Println(len(teacher.Students))\n" +
+				"// Output: 2",
+		},
+	}, doc.Examples)
+
+	t.Run("disabled", func(t *testing.T) {
+		doc, err := Generate(validator)
+		require.NoError(t, err)
+		assert.Empty(t, doc.Examples)
+	})
+}
+
+func TestGenerate_GenericWrappers(t *testing.T) {
+	t.Run("wrapped generate", func(t *testing.T) {
+		doc, err := generateWrapped[testmodels.Teacher]()
+		require.NoError(t, err)
+		assert.Equal(t, "Teacher", findProperty(t, doc, "$").TypeInfo.Name)
+		assert.Contains(t, findProperty(t, doc, "$").TypeDoc, "Teacher is a sample struct used for testing.")
+	})
+
+	t.Run("nested wrappers", func(t *testing.T) {
+		doc, err := generateNestedWrapped[*testmodels.Teacher]()
+		require.NoError(t, err)
+		assert.Equal(t, "Teacher", findProperty(t, doc, "$").TypeInfo.Name)
+	})
+
+	t.Run("generic method", func(t *testing.T) {
+		doc, err := genericGenerator[[]testmodels.Student]{}.Generate()
+		require.NoError(t, err)
+		assert.Equal(t, "[]Student", findProperty(t, doc, "$").TypeInfo.Name)
+		assert.Contains(t, findProperty(t, doc, "$[*]").TypeDoc, "Student is just a teacher!")
+	})
+
+	t.Run("generic type", func(t *testing.T) {
+		doc, err := generateWrapped[testmodels.Page[testmodels.Teacher]]()
+		require.NoError(t, err)
+		root := findProperty(t, doc, "$")
+		assert.Equal(t, "Page[github.com/nieomylnieja/govydoc/internal/testmodels.Teacher]", root.TypeInfo.Name)
+		assert.Equal(t, "Page is a generic page of results.", root.TypeDoc)
+		assert.Equal(t, "Items are the results on the page.", findProperty(t, doc, "$.items").FieldDoc)
+		assert.Contains(t, findProperty(t, doc, "$.items[*]").TypeDoc, "Teacher is a sample struct used for testing.")
+		assert.Empty(t, doc.DocWarnings)
+	})
+
+	t.Run("generic struct fields", func(t *testing.T) {
+		doc, err := Generate(govy.New[testmodels.Box[testmodels.Teacher]]())
+		require.NoError(t, err)
+		value := findProperty(t, doc, "$.value")
+		assert.Equal(t, "Teacher", value.TypeInfo.Name)
+		assert.Equal(t, "Value is the boxed value.", value.FieldDoc)
+		assert.Contains(t, value.TypeDoc, "Teacher is a sample struct used for testing.")
+		assert.Contains(t, propertyPaths(doc), "$.value.students[*].name")
+		assert.Empty(t, doc.DocWarnings)
+	})
+
+	t.Run("embedded generic structs", func(t *testing.T) {
+		doc, err := Generate(govy.New[testmodels.LabeledBoxes]())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"$.value", "$.label", "$.pair", "$.color"}, findProperty(t, doc, "$").ChildrenPaths)
+		assert.Equal(t, "Value is the boxed value.", findProperty(t, doc, "$.value").FieldDoc)
+		pair := findProperty(t, doc, "$.pair")
+		assert.Equal(t, "Pair is the labeled pair.", pair.FieldDoc)
+		assert.Equal(t, "Pair holds a value with its key.", pair.TypeDoc)
+		assert.Equal(t, "string", findProperty(t, doc, "$.pair.key").TypeInfo.Name)
+		assert.Equal(t, "Student", findProperty(t, doc, "$.pair.entry").TypeInfo.Name)
+		assert.Empty(t, doc.DocWarnings)
+	})
+
+	t.Run("unnamed types", func(t *testing.T) {
+		for name, generate := range map[string]func() (ObjectDoc, error){
+			"interface":        generateWrapped[any],
+			"built-in":         generateWrapped[int],
+			"anonymous struct": generateWrapped[struct{ Name string }],
+			"map of any":       generateNestedWrapped[map[string]any],
+		} {
+			_, err := generate()
+			assert.ErrorContains(t, err, "type must be a named type declared in a package", name)
+		}
+	})
+}
+
+func generateWrapped[T any]() (ObjectDoc, error) {
+	return Generate(govy.New[T]())
+}
+
+func generateNestedWrapped[T any]() (ObjectDoc, error) {
+	return generateWrapped[T]()
+}
+
+type genericGenerator[T any] struct{}
+
+func (genericGenerator[T]) Generate() (ObjectDoc, error) {
+	return Generate(govy.New[T]())
+}
+
+func TestWithResolveExampleReferences(t *testing.T) {
+	validator := govy.New[testmodels.Invoice]().WithName("Invoice")
+	expectedExamples := []Example{
+		{Name: "invoice.json", Content: "{\n  \"number\": \"INV-001\",\n  \"total\": 100\n}\n"},
+		{Name: "invoice_total.json", Content: "100\n"},
+	}
+
+	for _, format := range []DocFormat{DocMarkdown, DocHTML, DocPlain} {
+		t.Run(string(format), func(t *testing.T) {
+			doc, err := Generate(validator,
+				WithResolveExampleReferences("testdata/examples"),
+				WithDocFormat(format),
+			)
+			require.NoError(t, err)
+
+			assert.Equal(t, expectedExamples, doc.Examples)
+			assert.NotContains(t, findProperty(t, doc, "$").TypeDoc, "Example file:")
+			assert.Contains(t, findProperty(t, doc, "$").TypeDoc, "Invoice is a bill for delivered services.")
+			assert.NotContains(t, findProperty(t, doc, "$.total").FieldDoc, "Example file:")
+			assert.Contains(t, findProperty(t, doc, "$.total").FieldDoc, "Total is the amount due.")
+		})
+	}
+
+	t.Run("custom marker", func(t *testing.T) {
+		doc, err := Generate(validator,
+			WithResolveExampleReferences("testdata/examples"),
+			WithExampleReferenceMarker("See:"),
+		)
+		require.NoError(t, err)
+		assert.Empty(t, doc.Examples)
+		assert.Contains(t, findProperty(t, doc, "$").TypeDoc, "Example file: invoice.json")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := Generate(validator, WithResolveExampleReferences(t.TempDir()))
+		require.ErrorContains(t, err, "failed to read referenced example file")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		doc, err := Generate(validator)
+		require.NoError(t, err)
+		assert.Empty(t, doc.Examples)
+		assert.Contains(t, findProperty(t, doc, "$").TypeDoc, "Example file: invoice.json")
+	})
+}
+
+func TestWithTagName(t *testing.T) {
+	validator := govy.New(
+		govy.For(func(c testmodels.LoggingConfig) string { return c.LogLevel }).
+			WithName("log_level").
+			Rules(rules.OneOf("debug", "info")),
+	)
+
+	doc, err := Generate(validator, WithTagName("yaml"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"$", "$.log_level", "$.retries"}, propertyPaths(doc))
+	logLevel := findProperty(t, doc, "$.log_level")
+	assert.Equal(t, "LogLevel is the minimum level of logged messages.", logLevel.FieldDoc)
+	assert.Len(t, logLevel.Rules, 1)
+	assert.Empty(t, doc.PlanWarnings)
+
+	doc, err = Generate(govy.New[testmodels.LoggingConfig]())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"$", "$.logLevel"}, propertyPaths(doc))
+}
+
+func TestWithUntaggedFields(t *testing.T) {
+	validator := govy.New[testmodels.Teacher]().WithName("Teacher")
+
+	tests := map[string]struct {
+		opts     []GenerateOption
+		expected bool
+	}{
+		"default":        {},
+		"skip":           {opts: []GenerateOption{WithUntaggedFields(UntaggedFieldsSkip)}},
+		"use field name": {opts: []GenerateOption{WithUntaggedFields(UntaggedFieldsUseFieldName)}, expected: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			doc, err := Generate(validator, test.opts...)
+			require.NoError(t, err)
+
+			paths := propertyPaths(doc)
+			if !test.expected {
+				assert.NotContains(t, paths, "$.NoTag")
+				return
+			}
+			assert.Contains(t, paths, "$.NoTag")
+			assert.Contains(t, findProperty(t, doc, "$").ChildrenPaths, "$.NoTag")
+			assert.Equal(t, "int", findProperty(t, doc, "$.NoTag").TypeInfo.Name)
+			assert.Contains(t, paths, "$.university.SquareMeters")
+		})
+	}
+
+	t.Run("embedded struct", func(t *testing.T) {
+		doc, err := Generate(
+			govy.New[testmodels.Resident]().WithName("Resident"),
+			WithUntaggedFields(UntaggedFieldsUseFieldName),
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"$", "$.name", "$.city", "$.state"}, propertyPaths(doc))
+	})
+}
+
+func TestGenerate_NestedPropertyNames(t *testing.T) {
+	validator := govy.New(
+		govy.For(func(p testmodels.Person) string { return p.Address.City }).
+			WithName("address.city").
+			Rules(rules.EQ("Warsaw")),
+		govy.For(func(p testmodels.Person) string { return p.Address.State }).
+			WithName("address['state']").
+			Rules(rules.EQ("Masovia")),
+	).WithName("Person")
+
+	doc, err := Generate(validator)
+	require.NoError(t, err)
+
+	assert.Empty(t, doc.PlanWarnings)
+	city := findProperty(t, doc, "$.address.city")
+	require.Len(t, city.Rules, 1)
+	assert.Equal(t, "must be equal to 'Warsaw'", city.Rules[0].Description)
+	state := findProperty(t, doc, "$.address.state")
+	require.Len(t, state.Rules, 1)
+	assert.Equal(t, "must be equal to 'Masovia'", state.Rules[0].Description)
+}
+
+func Test_pathSegmentName(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		path     string
+		expected string
+	}{
+		"root":          {path: "$"},
+		"name":          {path: "$.name", expected: "name"},
+		"nested name":   {path: "$.address.city", expected: "city"},
+		"quoted name":   {path: "$['a.b']", expected: "a.b"},
+		"escaped quote": {path: `$['it\'s.a']`, expected: "it's.a"},
+		"wildcard":      {path: "$.items[*]", expected: "[*]"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, pathSegmentName(jsonpath.Parse(test.path)))
+		})
+	}
+}
+
+func Test_renamePlanPath(t *testing.T) {
+	t.Parallel()
+
+	mapping := map[string]string{
+		"$.location":      "$.address",
+		"$.location.town": "$.address.city",
+		"$.loc":           "$.other",
+	}
+	tests := map[string]struct {
+		path     string
+		expected string
+	}{
+		"not mapped":      {path: "$.name", expected: "$.name"},
+		"mapped":          {path: "$.location", expected: "$.address"},
+		"descendant":      {path: "$.location.state", expected: "$.address.state"},
+		"longest prefix":  {path: "$.location.town.zip", expected: "$.address.city.zip"},
+		"segment prefix":  {path: "$.locations", expected: "$.locations"},
+		"slice of mapped": {path: "$.loc[*]", expected: "$.other[*]"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			for range 10 {
+				assert.Equal(t, test.expected, renamePlanPath(jsonpath.Parse(test.path), mapping).String())
+			}
+		})
+	}
+}
+
+func Test_expandNestedNames(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		path     jsonpath.Path
+		expected string
+	}{
+		"simple name":     {path: jsonpath.Parse("$.name"), expected: "$.name"},
+		"dotted name":     {path: jsonpath.NewRoot().Name("address.city"), expected: "$.address.city"},
+		"bracket name":    {path: jsonpath.NewRoot().Name("address['state']"), expected: "$.address.state"},
+		"slice element":   {path: jsonpath.NewRoot().Name("items[*].name"), expected: "$.items[*].name"},
+		"nested segments": {path: jsonpath.NewRoot().Name("a").Name("b.c"), expected: "$.a.b.c"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, expandNestedNames(test.path).String())
+		})
+	}
+}
+
+func TestGenerate_PackageDoc(t *testing.T) {
+	validator := govy.New[testmodels.Teacher]().WithName("Teacher")
+
+	doc, err := Generate(validator)
+	require.NoError(t, err)
+	assert.Equal(t,
+		"Package testmodels declares the models documented in tests. "+
+			"See [Teacher](https://pkg.go.dev/github.com/nieomylnieja/govydoc/internal/testmodels#Teacher) "+
+			"for the most common one.",
+		doc.PackageDoc)
+
+	t.Run("plain text", func(t *testing.T) {
+		doc, err := Generate(validator, WithDocFormat(DocPlain))
+		require.NoError(t, err)
+		assert.Equal(t,
+			"Package testmodels declares the models documented in tests. See Teacher for the\nmost common one.",
+			doc.PackageDoc)
+	})
+
+	t.Run("package without comment", func(t *testing.T) {
+		doc, err := Generate(govy.New[moremodels.University]().WithName("University"))
+		require.NoError(t, err)
+		assert.Empty(t, doc.PackageDoc)
+	})
+}
+
+func TestGenerate_Deprecated(t *testing.T) {
+	doc, err := Generate(govy.New[testmodels.Enrollment]().WithName("Enrollment"))
+	require.NoError(t, err)
+
+	student := findProperty(t, doc, "$.student")
+	assert.Equal(t, "Student is the enrolled student.", student.FieldDoc)
+	assert.Equal(t, "Use StudentName instead.", student.DeprecatedDoc)
+	assert.NotContains(t, student.TypeDoc, "Deprecated")
+	assert.Equal(t, "Use Teacher instead.", student.TypeDeprecatedDoc)
+
+	oldName := findProperty(t, doc, "$.student.oldName")
+	assert.Equal(t, "Use Name instead.", oldName.DeprecatedDoc)
+	assert.Empty(t, oldName.TypeDeprecatedDoc)
+
+	studentName := findProperty(t, doc, "$.studentName")
+	assert.Empty(t, studentName.DeprecatedDoc)
+	assert.Empty(t, studentName.TypeDeprecatedDoc)
+}
+
+func TestWithTrailingComments(t *testing.T) {
+	validator := govy.New[testmodels.Teacher]().WithName("Teacher")
+
+	t.Run("enabled", func(t *testing.T) {
+		doc, err := Generate(validator, WithTrailingComments())
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			"Age is the age of the teacher. This is not a valid doc comment.",
+			findProperty(t, doc, "$.age").FieldDoc)
+		assert.Equal(t, "Name is the name of the teacher.", findProperty(t, doc, "$.name").FieldDoc)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		doc, err := Generate(validator)
+		require.NoError(t, err)
+
+		assert.Empty(t, findProperty(t, doc, "$.age").FieldDoc)
+	})
+}
+
+func TestWithDefaultTag(t *testing.T) {
+	validator := govy.New[testmodels.ServerConfig]().WithName("ServerConfig")
+
+	t.Run("enabled", func(t *testing.T) {
+		doc, err := Generate(validator, WithDefaultTag("default"))
+		require.NoError(t, err)
+
+		assert.Equal(t, "localhost", findProperty(t, doc, "$.host").DefaultValue)
+		assert.Equal(t, "8080", findProperty(t, doc, "$.port").DefaultValue)
+		assert.Empty(t, findProperty(t, doc, "$.debug").DefaultValue)
+		assert.Contains(t, mustMarshalJSON(t, findProperty(t, doc, "$.port")), `"defaultValue":"8080"`)
+	})
+
+	t.Run("parsed values", func(t *testing.T) {
+		doc, err := Generate(validator, WithDefaultTag("default"))
+		require.NoError(t, err)
+
+		assert.Equal(t, "localhost", findProperty(t, doc, "$.host").Default)
+		assert.Equal(t, int64(8080), findProperty(t, doc, "$.port").Default)
+		assert.Equal(t, 0.5, findProperty(t, doc, "$.ratio").Default)
+		assert.Equal(t, "yes", findProperty(t, doc, "$.verbose").Default, "value which is not a valid bool")
+		assert.Nil(t, findProperty(t, doc, "$.debug").Default)
+		assert.Contains(t, mustMarshalJSON(t, findProperty(t, doc, "$.port")), `"default":8080`)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		doc, err := Generate(validator)
+		require.NoError(t, err)
+
+		assert.Empty(t, findProperty(t, doc, "$.host").DefaultValue)
+	})
+}
+
+func TestGenerate_TypeWithoutSource(t *testing.T) {
+	// Types declared in test files are not loaded by the documentation parser.
+	type classroom struct {
+		Number  int                `json:"number"`
+		Teacher testmodels.Teacher `json:"teacher"`
+	}
+	validator := govy.New(
+		govy.For(func(c classroom) int { return c.Number }).
+			WithName("number").
+			Rules(rules.GT(0)),
+	).WithName("Classroom")
+
+	doc, err := Generate(validator)
+	require.NoError(t, err)
+
+	require.Len(t, doc.DocWarnings, 1)
+	assert.Contains(t, doc.DocWarnings[0], "type govydoc.classroom is not documented")
+	root := findProperty(t, doc, "$")
+	assert.Equal(t, "classroom", root.TypeInfo.Name)
+	assert.Empty(t, root.TypeDoc)
+	number := findProperty(t, doc, "$.number")
+	assert.Equal(t, "int", number.TypeInfo.Name)
+	assert.Empty(t, number.FieldDoc)
+	require.Len(t, number.Rules, 1)
+	assert.Contains(t, findProperty(t, doc, "$.teacher").TypeDoc, "Teacher is a sample struct used for testing.")
+}
+
+func TestGenerate_ScalarTypes(t *testing.T) {
+	validator := govy.New[testmodels.Measurement]().WithName("Measurement")
+
+	t.Run("json.Number", func(t *testing.T) {
+		doc, err := Generate(validator)
+		require.NoError(t, err)
+
+		value := findProperty(t, doc, "$.value")
+		assert.Equal(t, "Number", value.TypeInfo.Name)
+		assert.Equal(t, "number", value.TypeInfo.Kind)
+		assert.Equal(t, "An arbitrary-precision JSON number, for example 3.14 or 1e100.", value.TypeDoc)
+		assert.Equal(t, "Value is the measured value.", value.FieldDoc)
+		assert.Equal(t, "[]number", findProperty(t, doc, "$.history").TypeInfo.Kind)
+		assert.Equal(t, "number", findProperty(t, doc, "$.history[*]").TypeInfo.Kind)
+		assert.Equal(t, "struct", findProperty(t, doc, "$.sensor").TypeInfo.Kind)
+		assert.Contains(t, propertyPaths(doc), "$.sensor.id")
+	})
+
+	t.Run("time types", func(t *testing.T) {
+		doc, err := Generate(validator)
+		require.NoError(t, err)
+
+		interval := findProperty(t, doc, "$.interval")
+		assert.Equal(t, "duration", interval.TypeInfo.Kind)
+		assert.Contains(t, interval.TypeDoc, `"30s"`)
+		assert.Equal(t, "Interval is the time between measurements.", interval.FieldDoc)
+		measuredAt := findProperty(t, doc, "$.measuredAt")
+		assert.Equal(t, "datetime", measuredAt.TypeInfo.Kind)
+		assert.Contains(t, measuredAt.TypeDoc, "RFC 3339")
+		assert.Empty(t, measuredAt.ChildrenPaths)
+	})
+
+	t.Run("custom scalar type", func(t *testing.T) {
+		doc, err := Generate(validator,
+			WithScalarType[testmodels.Sensor]("string", "Sensor identifier."),
+			WithScalarType[json.Number]("decimal", "Decimal number."),
+		)
+		require.NoError(t, err)
+
+		sensor := findProperty(t, doc, "$.sensor")
+		assert.Equal(t, "string", sensor.TypeInfo.Kind)
+		assert.Equal(t, "Sensor identifier.", sensor.TypeDoc)
+		assert.Empty(t, sensor.ChildrenPaths)
+		assert.NotContains(t, propertyPaths(doc), "$.sensor.id")
+		value := findProperty(t, doc, "$.value")
+		assert.Equal(t, "decimal", value.TypeInfo.Kind)
+		assert.Equal(t, "Decimal number.", value.TypeDoc)
+	})
+}
+
+func TestGenerate_ScalarInterfaces(t *testing.T) {
+	validator := govy.New[testmodels.Server]().WithName("Server")
+
+	t.Run("encoding.TextMarshaler", func(t *testing.T) {
+		doc, err := Generate(validator)
+		require.NoError(t, err)
+
+		address := findProperty(t, doc, "$.address")
+		assert.Equal(t, "IP", address.TypeInfo.Name)
+		assert.Equal(t, "string", address.TypeInfo.Kind)
+		assert.Equal(t, "Address is the IP address of the server.", address.FieldDoc)
+		assert.Empty(t, address.ChildrenPaths)
+		gateway := findProperty(t, doc, "$.gateway")
+		assert.Equal(t, "string", gateway.TypeInfo.Kind)
+		assert.True(t, gateway.AllowsNull)
+		assert.Empty(t, gateway.ChildrenPaths)
+		serial := findProperty(t, doc, "$.serial")
+		assert.Equal(t, "string", serial.TypeInfo.Kind)
+		assert.Contains(t, serial.TypeDoc, `encoded as text, like "ACME-42"`)
+		assert.Equal(t, "Serial identifies the server's hardware.", serial.FieldDoc)
+		assert.Empty(t, serial.ChildrenPaths)
+		assert.Equal(t, "[]string", findProperty(t, doc, "$.tags").TypeInfo.Kind)
+		assert.Equal(t, "string", findProperty(t, doc, "$.tags[*]").TypeInfo.Kind)
+		assert.NotContains(t, propertyPaths(doc), "$.serial.vendor")
+		assert.NotContains(t, propertyPaths(doc), "$.tags[*].vendor")
+	})
+
+	t.Run("encoded like encoding/json", func(t *testing.T) {
+		doc, err := Generate(validator)
+		require.NoError(t, err)
+
+		data, err := json.Marshal(testmodels.Server{
+			Serial:      testmodels.SerialNumber{Vendor: "ACME", Number: 42},
+			Location:    testmodels.Location{Rack: "A", Slot: 1},
+			Fingerprint: testmodels.Fingerprint{Algorithm: "sha256", Digest: "abc"},
+		})
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"serial":"ACME-42"`)
+		assert.Contains(t, string(data), `"location":{"rack":"A","slot":1}`)
+		assert.Contains(t, string(data), `"fingerprint":{"algorithm":"sha256","digest":"abc"}`)
+
+		// MarshalJSON takes precedence over MarshalText.
+		assert.Equal(t, "struct", findProperty(t, doc, "$.location").TypeInfo.Kind)
+		assert.Contains(t, propertyPaths(doc), "$.location.rack")
+		// MarshalText with a pointer receiver is not used for values of struct fields.
+		assert.Equal(t, "struct", findProperty(t, doc, "$.fingerprint").TypeInfo.Kind)
+		assert.Contains(t, propertyPaths(doc), "$.fingerprint.digest")
+	})
+
+	t.Run("unregistered interface", func(t *testing.T) {
+		doc, err := Generate(validator, WithScalarInterface((*encoding.TextMarshaler)(nil), ""))
+		require.NoError(t, err)
+
+		assert.Equal(t, "struct", findProperty(t, doc, "$.serial").TypeInfo.Kind)
+		assert.Contains(t, propertyPaths(doc), "$.serial.vendor")
+		assert.Contains(t, propertyPaths(doc), "$.tags[*].number")
+	})
+
+	t.Run("custom interface", func(t *testing.T) {
+		doc, err := Generate(validator,
+			WithScalarInterface((*encoding.TextMarshaler)(nil), "text"),
+			WithScalarInterface((*fmt.Stringer)(nil), "stringer"),
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, "text", findProperty(t, doc, "$.serial").TypeInfo.Kind)
+		assert.Equal(t, "text", findProperty(t, doc, "$.address").TypeInfo.Kind)
+		assert.Equal(t, "[]text", findProperty(t, doc, "$.tags").TypeInfo.Kind)
+	})
+
+	t.Run("scalar type takes precedence", func(t *testing.T) {
+		doc, err := Generate(validator, WithScalarType[net.IP]("ip", "IPv4 or IPv6 address."))
+		require.NoError(t, err)
+
+		address := findProperty(t, doc, "$.address")
+		assert.Equal(t, "ip", address.TypeInfo.Kind)
+		assert.Equal(t, "IPv4 or IPv6 address.", address.TypeDoc)
+		assert.Equal(t, "string", findProperty(t, doc, "$.serial").TypeInfo.Kind)
+	})
+
+	t.Run("invalid interface", func(t *testing.T) {
+		_, err := Generate(validator, WithScalarInterface(testmodels.SerialNumber{}, "string"))
+		require.EqualError(t, err, "invalid scalar interface testmodels.SerialNumber: "+
+			"interface must be passed as a nil pointer, e.g. (*json.Marshaler)(nil)")
+	})
+}
+
+func TestGenerate_AnonymousStructs(t *testing.T) {
+	validator := govy.New[testmodels.Pipeline]().WithName("Pipeline")
+
+	doc, err := Generate(validator, WithDeclarationOrder())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"$",
+		"$.config",
+		"$.config.host",
+		"$.config.retry",
+		"$.config.retry.attempts",
+		"$.steps",
+		"$.steps[*]",
+		"$.steps[*].name",
+		"$.steps[*].owner",
+	}, propertyPaths(doc)[:9])
+	config := findProperty(t, doc, "$.config")
+	assert.Equal(t, "struct", config.TypeInfo.Kind)
+	assert.Equal(t, "Config configures the pipeline.", config.FieldDoc)
+	assert.Empty(t, config.TypeDoc)
+	assert.Equal(t, []string{"$.config.host", "$.config.retry"}, config.ChildrenPaths)
+	assert.Equal(t, "Host runs the pipeline.", findProperty(t, doc, "$.config.host").FieldDoc)
+	assert.Equal(t, "Attempts limits the retries.", findProperty(t, doc, "$.config.retry.attempts").FieldDoc)
+	assert.Equal(t, "Name identifies the step.", findProperty(t, doc, "$.steps[*].name").FieldDoc)
+	owner := findProperty(t, doc, "$.steps[*].owner")
+	assert.Equal(t, "Owner is responsible for the step.", owner.FieldDoc)
+	assert.Contains(t, owner.TypeDoc, "Student is just a teacher")
+}
+
+func TestGenerate_NamesRequiringEscaping(t *testing.T) {
+	validator := govy.New(
+		govy.For(func(d testmodels.Deployment) testmodels.Component { return d.Component }).
+			WithName("app.kubernetes.io/component").
+			Include(govy.New(
+				govy.For(func(c testmodels.Component) string { return c.Version }).
+					WithName("app.version").
+					Rules(rules.StringNotEmpty()),
+			)),
+	).WithName("Deployment")
+
+	doc, err := Generate(validator)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"$",
+		"$.name",
+		"$['app.kubernetes.io/component']",
+		"$['app.kubernetes.io/component']['app.version']",
+		"$['app.kubernetes.io/component'].replicas",
+	}, propertyPaths(doc))
+	assert.Equal(t,
+		[]string{"$.name", "$['app.kubernetes.io/component']"},
+		findProperty(t, doc, "$").ChildrenPaths)
+	component := findProperty(t, doc, "$['app.kubernetes.io/component']")
+	assert.Equal(t, "Component is the deployed component.", component.FieldDoc)
+	assert.Equal(t, []string{
+		"$['app.kubernetes.io/component']['app.version']",
+		"$['app.kubernetes.io/component'].replicas",
+	}, component.ChildrenPaths)
+	version := findProperty(t, doc, "$['app.kubernetes.io/component']['app.version']")
+	assert.Equal(t, "Version is the component's version.", version.FieldDoc)
+	require.Len(t, version.Rules, 1)
+	assert.Equal(t, rules.ErrorCodeStringNotEmpty, version.Rules[0].ErrorCode)
+	assert.Empty(t, version.ChildrenPaths)
+	require.NoError(t, doc.Validate())
+}
+
+func TestWithFilteredRules(t *testing.T) {
+	traceRule := govy.NewRule(func(string) error { return nil }).
+		WithErrorCode("trace").
+		WithDescription("traced for auditing")
+	validator := govy.New(
+		govy.For(func(t testmodels.Teacher) string { return t.Name }).
+			WithName("name").
+			Rules(
+				rules.StringNotEmpty(),
+				traceRule,
+				rules.StringMaxLength(10),
+			),
+		govy.For(func(t testmodels.Teacher) string { return t.Hobby }).
+			WithName("hobby").
+			Rules(govy.NewRuleSet(traceRule).WithErrorCode("audit")),
+	).WithName("Teacher")
+
+	doc, err := Generate(validator, WithFilteredRules("trace"))
+	require.NoError(t, err)
+
+	name := findProperty(t, doc, "$.name")
+	require.Len(t, name.Rules, 2)
+	assert.Equal(t, rules.ErrorCodeStringNotEmpty, name.Rules[0].ErrorCode)
+	assert.Equal(t, rules.ErrorCodeStringMaxLength, name.Rules[1].ErrorCode)
+	assert.Equal(t, &Constraints{MaxLen: ptr(10)}, name.Constraints)
+	assert.Empty(t, findProperty(t, doc, "$.hobby").Rules)
+
+	doc, err = Generate(validator)
+	require.NoError(t, err)
+	assert.Len(t, findProperty(t, doc, "$.name").Rules, 3)
+	hobby := findProperty(t, doc, "$.hobby")
+	require.Len(t, hobby.Rules, 1)
+	assert.Equal(t, govy.ErrorCode("audit:trace"), hobby.Rules[0].ErrorCode)
+}
+
+func TestWithTypeKindFilter(t *testing.T) {
+	validator := govy.New[testmodels.Teacher]().WithName("Teacher")
+
+	t.Run("scalars", func(t *testing.T) {
+		doc, err := Generate(validator, WithTypeKindFilter("string", "int"))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			"$.name",
+			"$.hobby",
+			"$.age",
+			"$.students[*].age",
+			"$.students[*].name",
+			"$.students[*].oldName",
+		}, propertyPaths(doc))
+		assert.Equal(t, "Name is the name of the teacher.", findProperty(t, doc, "$.name").FieldDoc)
+		assert.Equal(t, "Age is life!", findProperty(t, doc, "$.students[*].age").FieldDoc)
+	})
+
+	t.Run("children paths", func(t *testing.T) {
+		doc, err := Generate(validator, WithTypeKindFilter("struct"), WithTypeKindFilter("string"))
+		require.NoError(t, err)
+
+		// $.students[*] is attached to the root, as $.students is omitted.
+		assert.Equal(t,
+			[]string{"$.name", "$.hobby", "$.students[*]", "$.university"},
+			findProperty(t, doc, "$").ChildrenPaths)
+		assert.NotContains(t, propertyPaths(doc), "$.students")
+		assert.Equal(t,
+			[]string{"$.students[*].name", "$.students[*].oldName"},
+			findProperty(t, doc, "$.students[*]").ChildrenPaths)
+		reachable := map[string]bool{"$": true}
+		for _, property := range doc.Properties {
+			assert.True(t, reachable[property.Path.String()], "%s is not reachable from the root", property.Path)
+			for _, childPath := range property.ChildrenPaths {
+				assert.Contains(t, propertyPaths(doc), childPath)
+				reachable[childPath] = true
+			}
+		}
+	})
+
+	t.Run("nearest documented ancestor", func(t *testing.T) {
+		doc, err := Generate(validator, WithTypeKindFilter("[]struct", "string"))
+		require.NoError(t, err)
+
+		students := findProperty(t, doc, "$.students")
+		assert.Equal(t, []string{"$.students[*].name", "$.students[*].oldName"}, students.ChildrenPaths)
+		assert.NotContains(t, propertyPaths(doc), "$.students[*]")
+	})
+}
+
+func TestWithRuleFilter(t *testing.T) {
+	validator := govy.New(
+		govy.For(func(t testmodels.Teacher) string { return t.Name }).
+			WithName("name").
+			Rules(rules.StringMaxLength(10)),
+		govy.For(func(t testmodels.Teacher) string { return t.Hobby }).
+			WithName("hobby").
+			Rules(rules.StringMaxLength(20)),
+		govy.For(func(t testmodels.Teacher) string { return t.Hobby }).
+			WithName("hobby").
+			Rules(rules.Forbidden[string]()).
+			When(func(t testmodels.Teacher) bool { return t.Age > 30 }, govy.WhenDescription("when above 30")),
+	).WithName("Teacher")
+
+	isConditional := func(rule RuleDoc) bool { return len(rule.Conditions) > 0 }
+	doc, err := Generate(validator, WithRuleFilter(isConditional))
+	require.NoError(t, err)
+
+	hobby := findProperty(t, doc, "$.hobby")
+	require.Len(t, hobby.RuleDocs, 1)
+	assert.Equal(t, "string_max_length", hobby.RuleDocs[0].Name)
+	assert.Len(t, findProperty(t, doc, "$.name").Rules, 1)
+
+	doc, err = Generate(
+		validator,
+		WithRuleFilter(isConditional),
+		WithRuleFilter(func(rule RuleDoc) bool { return rule.Parameters["max"] == 10 }),
+	)
+	require.NoError(t, err)
+	assert.Empty(t, findProperty(t, doc, "$.name").Rules)
+	assert.Nil(t, findProperty(t, doc, "$.name").Constraints)
+	assert.Len(t, findProperty(t, doc, "$.hobby").Rules, 1)
+}
+
+func TestWithPropertyTransform(t *testing.T) {
+	validator := govy.New(
+		govy.For(func(t testmodels.Teacher) string { return t.Name }).
+			WithName("name").
+			Rules(rules.StringMaxLength(10)),
+	).WithName("Teacher")
+
+	var transformed []string
+	doc, err := Generate(validator,
+		WithFilteredPaths("$.students"),
+		WithArrayToken("[]"),
+		WithStableIDs(func(property PropertyDoc) string { return "id:" + property.Path.String() }),
+		WithPropertyTransform(func(property PropertyDoc) PropertyDoc {
+			transformed = append(transformed, property.Path.String())
+			assert.Equal(t, "id:"+property.Path.String(), property.ID)
+			assert.NotContains(t, property.Path.String(), "[*]")
+			if property.Path.String() == "$.name" {
+				property.FieldDoc = "[REDACTED]"
+			}
+			return property
+		}),
+		WithPropertyTransform(func(property PropertyDoc) PropertyDoc {
+			property.FieldDoc = strings.ToLower(property.FieldDoc)
+			return property
+		}),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, propertyPaths(doc), transformed)
+	name := findProperty(t, doc, "$.name")
+	assert.Equal(t, "[redacted]", name.FieldDoc)
+	require.Len(t, name.RuleDocs, 1)
+	assert.Equal(t, "string_max_length", name.RuleDocs[0].Name)
+}
+
+func TestWithInterfaceMethods(t *testing.T) {
+	validator := govy.New[testmodels.Teacher]()
+
+	doc, err := Generate(validator, WithInterfaceMethods())
+	require.NoError(t, err)
+
+	stringer := findProperty(t, doc, "$.stringer")
+	require.Len(t, stringer.Methods, 1)
+	assert.Equal(t, "String", stringer.Methods[0].Name)
+	assert.Equal(t, "String() string", stringer.Methods[0].Signature)
+	assert.Nil(t, findProperty(t, doc, "$.name").Methods)
+
+	doc, err = Generate(validator)
+	require.NoError(t, err)
+	assert.Nil(t, findProperty(t, doc, "$.stringer").Methods)
+}
+
+func TestWithInterfaceImplementations(t *testing.T) {
+	validator := govy.New[testmodels.Alert]().WithName("Alert")
+
+	doc, err := Generate(validator, WithInterfaceImplementations(
+		(*testmodels.Channel)(nil),
+		testmodels.EmailChannel{},
+		&testmodels.WebhookChannel{},
+	))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"$",
+		"$.name",
+		"$.channel",
+		"$.channel['(EmailChannel)']",
+		"$.channel['(EmailChannel)'].address",
+		"$.channel['(WebhookChannel)']",
+		"$.channel['(WebhookChannel)'].url",
+	}, propertyPaths(doc))
+	require.NoError(t, doc.Validate())
+	channel := findProperty(t, doc, "$.channel")
+	assert.True(t, channel.IsInterface)
+	assert.Equal(t,
+		[]string{"$.channel['(EmailChannel)']", "$.channel['(WebhookChannel)']"},
+		channel.ChildrenPaths)
+	email := findProperty(t, doc, "$.channel['(EmailChannel)']")
+	assert.Equal(t, PathRoleImplementation, email.PathRole)
+	assert.False(t, email.IsInterface)
+	assert.Equal(t, "EmailChannel delivers alerts by email.", email.TypeDoc)
+	assert.Equal(t,
+		"Address is the recipient's email address.",
+		findProperty(t, doc, "$.channel['(EmailChannel)'].address").FieldDoc)
+	assert.Equal(t,
+		"WebhookChannel delivers alerts to an HTTP endpoint.",
+		findProperty(t, doc, "$.channel['(WebhookChannel)']").TypeDoc)
+	assert.Empty(t, doc.DocWarnings)
+
+	t.Run("without implementations", func(t *testing.T) {
+		doc, err := Generate(validator)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"$", "$.name", "$.channel"}, propertyPaths(doc))
+		assert.True(t, findProperty(t, doc, "$.channel").IsInterface)
+		assert.False(t, findProperty(t, doc, "$.name").IsInterface)
+	})
+
+	t.Run("invalid interface", func(t *testing.T) {
+		_, err := Generate(validator, WithInterfaceImplementations(testmodels.EmailChannel{}))
+		require.EqualError(t, err, "invalid interface testmodels.EmailChannel: "+
+			"interface must be passed as a nil pointer, e.g. (*fmt.Stringer)(nil)")
+	})
+
+	t.Run("invalid implementation", func(t *testing.T) {
+		_, err := Generate(validator, WithInterfaceImplementations(
+			(*testmodels.Channel)(nil),
+			testmodels.WebhookChannel{},
+		))
+		require.EqualError(t, err, "invalid implementation testmodels.WebhookChannel: "+
+			"type does not implement testmodels.Channel")
+	})
+}
+
+func TestWithSortedPaths(t *testing.T) {
+	t.Parallel()
+
+	doc, err := Generate(govy.New[testmodels.Shape]().WithName("Shape"), WithSortedPaths())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"$", "$.color", "$.size"}, propertyPaths(doc))
+
+	doc, err = Generate(govy.New[testmodels.TreeNode]().WithName("TreeNode"), WithSortedPaths())
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"$",
+		"$.children",
+		"$.children[*]",
+		"$.named",
+		"$.named.*~",
+		"$.named.*",
+	}, propertyPaths(doc))
+
+	doc, err = Generate(govy.New[testmodels.Teacher]().WithName("Teacher"), WithSortedPaths(), WithArrayToken("[]"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"$",
+		"$.age",
+		"$.hobby",
+		"$.name",
+		"$.stringer",
+		"$.students",
+		"$.students[]",
+		"$.students[].age",
+		"$.students[].name",
+		"$.students[].oldName",
+		"$.university",
+	}, propertyPaths(doc))
+}
+
+func TestWithTypeMethods(t *testing.T) {
+	validator := govy.New[testmodels.Price]()
+
+	doc, err := Generate(validator, WithTypeMethods())
+	require.NoError(t, err)
+
+	currency := findProperty(t, doc, "$.currency")
+	assert.Equal(t, []MethodInfo{
+		{
+			Name:      "GovydocDescription",
+			Signature: "GovydocDescription() string",
+			Doc:       "GovydocDescription implements govydoc.Documenter.",
+		},
+		{
+			Name:      "Symbol",
+			Signature: "Symbol() string",
+			Doc:       `Symbol returns the currency's symbol, e.g. "$" for USD.`,
+		},
+	}, currency.Methods)
+	discount := findProperty(t, doc, "$.discount")
+	require.Len(t, discount.Methods, 1)
+	assert.Equal(t, "GovydocDescription", discount.Methods[0].Name)
+	assert.Nil(t, findProperty(t, doc, "$").Methods)
+	assert.Nil(t, findProperty(t, doc, "$.amount").Methods)
+
+	doc, err = Generate(validator)
+	require.NoError(t, err)
+	assert.Nil(t, findProperty(t, doc, "$.currency").Methods)
+
+	t.Run("interface", func(t *testing.T) {
+		doc, err := Generate(govy.New[testmodels.Teacher](), WithTypeMethods())
+		require.NoError(t, err)
+		assert.Nil(t, findProperty(t, doc, "$.stringer").Methods)
+	})
+}
+
+func TestWithSourcePositions(t *testing.T) {
+	t.Run("teacher", func(t *testing.T) {
+		doc, err := Generate(govy.New[testmodels.Teacher](), WithSourcePositions())
+		require.NoError(t, err)
+
+		const modelsFile = "internal/testmodels/models.go"
+		assert.Zero(t, findProperty(t, doc, "$").SourcePos)
+		assert.Equal(t,
+			SourcePos{File: modelsFile, Line: fieldDeclarationLine(t, modelsFile, "Teacher", "Name")},
+			findProperty(t, doc, "$.name").SourcePos)
+		assert.Equal(t,
+			SourcePos{File: modelsFile, Line: fieldDeclarationLine(t, modelsFile, "Teacher", "Students")},
+			findProperty(t, doc, "$.students").SourcePos)
+		assert.Zero(t, findProperty(t, doc, "$.students[*]").SourcePos)
+		assert.Equal(t, modelsFile, findProperty(t, doc, "$.students[*].age").SourcePos.File)
+	})
+
+	t.Run("promoted field", func(t *testing.T) {
+		doc, err := Generate(govy.New[testmodels.Resident](), WithSourcePositions())
+		require.NoError(t, err)
+
+		const modelsFile = "internal/testmodels/models.go"
+		assert.Equal(t,
+			SourcePos{File: modelsFile, Line: fieldDeclarationLine(t, modelsFile, "Address", "City")},
+			findProperty(t, doc, "$.city").SourcePos)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		doc, err := Generate(govy.New[testmodels.Teacher]())
+		require.NoError(t, err)
+
+		for _, property := range doc.Properties {
+			assert.Zero(t, property.SourcePos, property.Path.String())
+		}
+	})
+}
+
+func TestWithLayoutInfo(t *testing.T) {
+	t.Run("teacher", func(t *testing.T) {
+		doc, err := Generate(govy.New[testmodels.Teacher](), WithLayoutInfo())
+		require.NoError(t, err)
+
+		var teacher testmodels.Teacher
+		root := findProperty(t, doc, "$")
+		assert.Zero(t, root.FieldSize)
+		assert.Zero(t, root.FieldOffset)
+		name := findProperty(t, doc, "$.name")
+		assert.Equal(t, int(unsafe.Sizeof(teacher.Name)), name.FieldSize)
+		assert.Zero(t, name.FieldOffset)
+		age := findProperty(t, doc, "$.age")
+		assert.Equal(t, int(unsafe.Sizeof(teacher.Age)), age.FieldSize)
+		assert.Equal(t, int(unsafe.Offsetof(teacher.Age)), age.FieldOffset)
+		students := findProperty(t, doc, "$.students")
+		assert.Equal(t, int(unsafe.Sizeof(teacher.Students)), students.FieldSize)
+		assert.Equal(t, int(unsafe.Offsetof(teacher.Students)), students.FieldOffset)
+		assert.Zero(t, findProperty(t, doc, "$.students[*]").FieldSize)
+		studentAge := findProperty(t, doc, "$.students[*].age")
+		assert.Equal(t, int(unsafe.Sizeof(0)), studentAge.FieldSize)
+	})
+
+	t.Run("promoted through pointer", func(t *testing.T) {
+		doc, err := Generate(govy.New[testmodels.Resident](), WithLayoutInfo())
+		require.NoError(t, err)
+
+		assert.Equal(t, int(unsafe.Sizeof("")), findProperty(t, doc, "$.name").FieldSize)
+		city := findProperty(t, doc, "$.city")
+		assert.Zero(t, city.FieldSize)
+		assert.Zero(t, city.FieldOffset)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		doc, err := Generate(govy.New[testmodels.Teacher]())
+		require.NoError(t, err)
+
+		for _, property := range doc.Properties {
+			assert.Zero(t, property.FieldSize, property.Path.String())
+			assert.Zero(t, property.FieldOffset, property.Path.String())
+		}
+	})
+}
+
+func TestWithMinimalOutput(t *testing.T) {
+	validator := govy.New(
+		govy.For(func(t testmodels.Teacher) string { return t.Name }).
+			WithName("name").
+			Rules(rules.EQ("John")),
+	).WithName("Teacher")
+
+	doc, err := Generate(validator, WithMinimalOutput(), WithRawDocs(), WithDocBlocks())
+	require.NoError(t, err)
+
+	fullDoc, err := Generate(validator)
+	require.NoError(t, err)
+	assert.Equal(t, propertyPaths(fullDoc), propertyPaths(doc))
+	for _, property := range doc.Properties {
+		assert.Empty(t, property.TypeDoc, property.Path.String())
+		assert.Empty(t, property.FieldDoc, property.Path.String())
+		assert.Empty(t, property.RawTypeDoc, property.Path.String())
+		assert.Empty(t, property.RawFieldDoc, property.Path.String())
+		assert.Empty(t, property.TypeDocBlocks, property.Path.String())
+		assert.Empty(t, property.FieldDocBlocks, property.Path.String())
+		assert.Empty(t, property.DeprecatedDoc, property.Path.String())
+		assert.Empty(t, property.TypeDeprecatedDoc, property.Path.String())
+		assert.NotEmpty(t, property.TypeInfo.Kind, property.Path.String())
+	}
+	name := findProperty(t, doc, "$.name")
+	require.Len(t, name.Rules, 1)
+	assert.Equal(t, "must be equal to 'John'", name.Rules[0].Description)
+	assert.Less(t, len(mustMarshalJSON(t, doc)), len(mustMarshalJSON(t, fullDoc)))
+}
+
+//go:embed testdata/generate_output.json
+var expectedGenerateOutput []byte
+
+func mustMarshalJSON(t *testing.T, value any) string {
+	t.Helper()
+	data, err := json.Marshal(value)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func propertyPaths(doc ObjectDoc) []string {
+	paths := make([]string, 0, len(doc.Properties))
+	for _, property := range doc.Properties {
+		paths = append(paths, property.Path.String())
+	}
+	return paths
+}
+
+// fieldDeclarationLine returns the line of the field declaration in the struct type declared in file,
+// which is relative to the module root.
+func fieldDeclarationLine(t *testing.T, file, typeName, fieldName string) int {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("..", "..", file))
+	require.NoError(t, err)
+	inType := false
+	for i, line := range strings.Split(string(data), "\n") {
+		switch {
+		case strings.HasPrefix(line, "type "+typeName+" struct"):
+			inType = true
+		case inType && line == "}":
+			inType = false
+		case inType && strings.HasPrefix(strings.TrimSpace(line), fieldName+" "):
+			return i + 1
+		}
+	}
+	require.Failf(t, "field not found", "%s.%s in %s", typeName, fieldName, file)
+	return 0
+}
+
+func findProperty(t *testing.T, doc ObjectDoc, path string) PropertyDoc {
+	t.Helper()
+	for _, property := range doc.Properties {
+		if property.Path.String() == path {
+			return property
+		}
+	}
+	require.Failf(t, "property not found", "path: %s", path)
+	return PropertyDoc{}
 }
@@ -0,0 +1,165 @@
+package govydoc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/nieomylnieja/govydoc/internal/godoc"
+)
+
+// Generator documents validators of different types with the same Go documentation parser,
+// so that the module's packages are loaded only once.
+// Use [NewGenerator] to create it.
+// It is safe for concurrent use.
+type Generator struct {
+	goDocParser *godoc.Parser
+	opts        []GenerateOption
+	caches      documentationCaches
+}
+
+// NewGenerator loads the module's packages and returns a [Generator] applying opts to every documented validator.
+// The options passed to [NewAnyValidator] are applied after opts, overriding them.
+// It returns an error if opts are invalid or if the packages cannot be loaded.
+func NewGenerator(opts ...GenerateOption) (*Generator, error) {
+	return newGenerator(context.Background(), opts)
+}
+
+// newGenerator creates a [Generator], stopping loading the module's packages when ctx is done.
+func newGenerator(ctx context.Context, opts []GenerateOption) (*Generator, error) {
+	options, err := newGenerateOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	start := options.startProgress()
+	var goDocParser *godoc.Parser
+	if options.lazyLoading {
+		goDocParser, err = godoc.NewLazyParser(options.moduleRoots...)
+	} else {
+		goDocParser, err = godoc.NewParser(ctx, options.moduleRoots...)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Go documentation parser: %w", err)
+	}
+	options.reportProgress(ProgressPackagesLoaded, nil, goDocParser.NumPackages(), start)
+	return &Generator{goDocParser: goDocParser, opts: opts}, nil
+}
+
+// Generate returns documentation for the type handled by validator, see [Generate].
+func (g *Generator) Generate(validator AnyValidator) (ObjectDoc, error) {
+	return validator.generate(context.Background(), g, g.opts)
+}
+
+// WithLazyLoading returns an option that loads the module's packages on demand,
+// starting from the documented type's package, instead of loading every package of the module
+// and its dependencies up front, which reduces the startup time in large modules.
+// Lazily loaded packages are not loaded with their dependencies, whose types are read from export data.
+// Packages which fail to load are not documented, instead a warning is added to [ObjectDoc.DocWarnings].
+// When passed to [NewAnyValidator], it has no effect, as the [Generator] has already created its parser.
+func WithLazyLoading() GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.lazyLoading = true
+		return options
+	}
+}
+
+// WithModuleRoots returns an option that documents the packages of the Go modules in the paths,
+// e.g. the modules of a monorepo which split their types between them,
+// instead of discovering the modules from the current working directory.
+// By default, the modules used by the go.work workspace of the current working directory are documented,
+// or the current Go module if there is no workspace.
+// [Generate] returns an error if any of the paths does not contain a go.mod file.
+// When passed to [NewAnyValidator], it has no effect, as the [Generator] has already created its parser.
+func WithModuleRoots(paths ...string) GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.moduleRoots = append(options.moduleRoots, paths...)
+		return options
+	}
+}
+
+// sharedGenerators are lazily created by the first [Generate] call and reused by the subsequent ones.
+// They are keyed by whether they load packages lazily, see [WithLazyLoading],
+// and by the module roots they load packages from, see [WithModuleRoots].
+// The lock is only held to look up the entries, not while the generators are created.
+var sharedGenerators struct {
+	mu      sync.Mutex
+	entries map[sharedGeneratorKey]*sharedGeneratorEntry
+}
+
+type sharedGeneratorKey struct {
+	lazyLoading bool
+	// moduleRoots are the module roots joined with a null character.
+	moduleRoots string
+}
+
+// sharedGeneratorEntry holds a shared [Generator] once done is closed.
+type sharedGeneratorEntry struct {
+	done      chan struct{}
+	generator *Generator
+	err       error
+}
+
+// getSharedGenerator returns the [Generator] shared by [Generate] calls, creating it with ctx if needed.
+// Calls made while the generator is created wait for it until their ctx is done.
+// Failures, including cancellations, are not cached, hence the creation is retried by the next call.
+func getSharedGenerator(ctx context.Context, lazyLoading bool, moduleRoots []string) (*Generator, error) {
+	key := sharedGeneratorKey{lazyLoading: lazyLoading, moduleRoots: strings.Join(moduleRoots, "\x00")}
+	for {
+		entry, created := loadSharedGeneratorEntry(key)
+		if created {
+			return createSharedGenerator(ctx, key, entry, lazyLoading, moduleRoots)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-entry.done:
+		}
+		if entry.err == nil {
+			return entry.generator, nil
+		}
+		// The creation failed, e.g. because the context of the call creating it was canceled,
+		// it is retried with ctx.
+	}
+}
+
+// loadSharedGeneratorEntry returns the entry of the shared generator with the key,
+// adding a new one if there is none, in which case the caller must create the generator.
+func loadSharedGeneratorEntry(key sharedGeneratorKey) (entry *sharedGeneratorEntry, created bool) {
+	sharedGenerators.mu.Lock()
+	defer sharedGenerators.mu.Unlock()
+	if entry, ok := sharedGenerators.entries[key]; ok {
+		return entry, false
+	}
+	if sharedGenerators.entries == nil {
+		sharedGenerators.entries = make(map[sharedGeneratorKey]*sharedGeneratorEntry, 2)
+	}
+	entry = &sharedGeneratorEntry{done: make(chan struct{})}
+	sharedGenerators.entries[key] = entry
+	return entry, true
+}
+
+// createSharedGenerator creates the generator of the entry and removes the entry if the creation fails.
+func createSharedGenerator(
+	ctx context.Context,
+	key sharedGeneratorKey,
+	entry *sharedGeneratorEntry,
+	lazyLoading bool,
+	moduleRoots []string,
+) (*Generator, error) {
+	defer close(entry.done)
+	var opts []GenerateOption
+	if lazyLoading {
+		opts = append(opts, WithLazyLoading())
+	}
+	if len(moduleRoots) > 0 {
+		opts = append(opts, WithModuleRoots(moduleRoots...))
+	}
+	entry.generator, entry.err = newGenerator(ctx, opts)
+	if entry.err != nil {
+		sharedGenerators.mu.Lock()
+		delete(sharedGenerators.entries, key)
+		sharedGenerators.mu.Unlock()
+	}
+	return entry.generator, entry.err
+}
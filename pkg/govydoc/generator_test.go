@@ -0,0 +1,149 @@
+package govydoc
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/nobl9/govy/pkg/govy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nieomylnieja/govydoc/internal/testmodels"
+)
+
+func TestGenerator(t *testing.T) {
+	generator, err := NewGenerator(WithKind("config"))
+	require.NoError(t, err)
+
+	t.Run("different types", func(t *testing.T) {
+		teacherDoc, err := generator.Generate(NewAnyValidator(govy.New[testmodels.Teacher]()))
+		require.NoError(t, err)
+		personDoc, err := generator.Generate(NewAnyValidator(govy.New[testmodels.Person]()))
+		require.NoError(t, err)
+
+		assert.Equal(t, "Teacher", teacherDoc.Name)
+		assert.Equal(t, "Person", personDoc.Name)
+		assert.Equal(t, "config", personDoc.Kind)
+		assert.Contains(t, propertyPaths(personDoc), "$.address.city")
+	})
+
+	t.Run("validator options override generator options", func(t *testing.T) {
+		doc, err := generator.Generate(NewAnyValidator(govy.New[testmodels.Address](), WithKind("request")))
+		require.NoError(t, err)
+		assert.Equal(t, "request", doc.Kind)
+	})
+
+	t.Run("concurrent use", func(t *testing.T) {
+		var wg sync.WaitGroup
+		docs := make([]ObjectDoc, 4)
+		errs := make([]error, len(docs))
+		for i := range docs {
+			wg.Go(func() {
+				docs[i], errs[i] = generator.Generate(NewAnyValidator(govy.New[testmodels.Teacher]()))
+			})
+		}
+		wg.Wait()
+		for i := range docs {
+			require.NoError(t, errs[i])
+			assert.Equal(t, docs[0], docs[i])
+		}
+	})
+
+	t.Run("invalid validator option", func(t *testing.T) {
+		_, err := generator.Generate(NewAnyValidator(govy.New[testmodels.Address](), WithArrayToken("*")))
+		require.EqualError(t, err, `invalid array token "*": token must be enclosed in square brackets`)
+	})
+}
+
+func TestNewGenerator_InvalidOption(t *testing.T) {
+	_, err := NewGenerator(WithArrayToken("*"))
+	require.EqualError(t, err, `invalid array token "*": token must be enclosed in square brackets`)
+}
+
+func TestNewGenerator_CanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	_, err := newGenerator(ctx, nil)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func Test_getSharedGenerator_CanceledWhileWaiting(t *testing.T) {
+	moduleRoots := []string{t.TempDir()}
+	key := sharedGeneratorKey{moduleRoots: moduleRoots[0]}
+	// Simulate a generator which is being created by another call.
+	entry, created := loadSharedGeneratorEntry(key)
+	require.True(t, created)
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	_, err := getSharedGenerator(ctx, false, moduleRoots)
+	require.ErrorIs(t, err, context.Canceled)
+
+	_, err = createSharedGenerator(t.Context(), key, entry, false, moduleRoots)
+	require.ErrorContains(t, err, "failed to create Go documentation parser")
+	sharedGenerators.mu.Lock()
+	defer sharedGenerators.mu.Unlock()
+	assert.NotContains(t, sharedGenerators.entries, key, "failed creation must not be cached")
+}
+
+func TestNewGenerator_Progress(t *testing.T) {
+	var events []ProgressEvent
+	_, err := NewGenerator(WithProgress(func(event ProgressEvent) {
+		events = append(events, event)
+	}))
+	require.NoError(t, err)
+
+	require.Len(t, events, 1)
+	assert.Equal(t, ProgressPackagesLoaded, events[0].Stage)
+	assert.Nil(t, events[0].Type)
+	assert.Positive(t, events[0].Count)
+}
+
+func TestWithLazyLoading(t *testing.T) {
+	validator := govy.New[testmodels.Teacher]().WithName("Teacher")
+
+	t.Run("Generate", func(t *testing.T) {
+		expected, err := Generate(validator)
+		require.NoError(t, err)
+		actual, err := Generate(validator, WithLazyLoading())
+		require.NoError(t, err)
+
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("NewGenerator", func(t *testing.T) {
+		var loadedPackages int
+		generator, err := NewGenerator(WithLazyLoading(), WithProgress(func(event ProgressEvent) {
+			loadedPackages = event.Count
+		}))
+		require.NoError(t, err)
+		assert.Zero(t, loadedPackages)
+
+		doc, err := generator.Generate(NewAnyValidator(govy.New[testmodels.Person]()))
+		require.NoError(t, err)
+		assert.Contains(t, propertyPaths(doc), "$.address.city")
+		assert.NotEmpty(t, findProperty(t, doc, "$.address").TypeDoc)
+	})
+}
+
+func TestWithModuleRoots(t *testing.T) {
+	validator := govy.New[testmodels.Teacher]().WithName("Teacher")
+
+	t.Run("module root", func(t *testing.T) {
+		expected, err := Generate(validator)
+		require.NoError(t, err)
+		actual, err := Generate(validator, WithModuleRoots(filepath.Join("..", "..")), WithLazyLoading())
+		require.NoError(t, err)
+
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("invalid module root", func(t *testing.T) {
+		_, err := NewGenerator(WithModuleRoots("testdata"))
+		require.EqualError(t, err, "failed to create Go documentation parser: "+
+			"failed to find module root: invalid module root testdata: go.mod not found")
+	})
+}
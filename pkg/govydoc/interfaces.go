@@ -0,0 +1,104 @@
+package govydoc
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// WithInterfaceImplementations returns an option that documents the properties of interface type iface
+// with the properties of its concrete implementations impls, which are otherwise unknown.
+// The interface is passed as a nil pointer, e.g. (*fmt.Stringer)(nil),
+// and the implementations as values of their types, e.g. EmailChannel{} or &WebhookChannel{}.
+// Every implementation is documented as a child of the interface property under the name of its type
+// in parentheses, like in a type assertion, e.g. $.channel['(EmailChannel)'].name,
+// with the [PathRoleImplementation] role.
+// [Generate] returns an error if iface is not a pointer to an interface,
+// or if any of impls is not a named type implementing it.
+func WithInterfaceImplementations(iface any, impls ...any) GenerateOption {
+	return func(options generateOptions) generateOptions {
+		registration := interfaceImplementations{iface: reflect.TypeOf(iface)}
+		for _, impl := range impls {
+			registration.impls = append(registration.impls, reflect.TypeOf(impl))
+		}
+		options.interfaceImplementations = append(options.interfaceImplementations, registration)
+		return options
+	}
+}
+
+// interfaceImplementations is a registration of [WithInterfaceImplementations].
+type interfaceImplementations struct {
+	iface reflect.Type
+	impls []reflect.Type
+}
+
+// validate checks the registration and returns the interface type.
+func (i interfaceImplementations) validate() (reflect.Type, error) {
+	if i.iface == nil || i.iface.Kind() != reflect.Pointer || i.iface.Elem().Kind() != reflect.Interface {
+		return nil, fmt.Errorf("invalid interface %v: interface must be passed as a nil pointer, "+
+			"e.g. (*fmt.Stringer)(nil)", i.iface)
+	}
+	iface := i.iface.Elem()
+	for _, impl := range i.impls {
+		if impl == nil || !impl.Implements(iface) {
+			return nil, fmt.Errorf("invalid implementation %v: type does not implement %s", impl, iface)
+		}
+		if implementationName(impl) == "" {
+			return nil, fmt.Errorf("invalid implementation %s of %s: type must be a named type", impl, iface)
+		}
+	}
+	return iface, nil
+}
+
+// newInterfaceImplementations validates the registrations and maps the interfaces to their implementations.
+func newInterfaceImplementations(registrations []interfaceImplementations) (map[reflect.Type][]reflect.Type, error) {
+	if len(registrations) == 0 {
+		return nil, nil
+	}
+	implementations := make(map[reflect.Type][]reflect.Type, len(registrations))
+	for _, registration := range registrations {
+		iface, err := registration.validate()
+		if err != nil {
+			return nil, err
+		}
+		implementations[iface] = append(implementations[iface], registration.impls...)
+	}
+	return implementations, nil
+}
+
+// implementationName returns the name of impl's type, without pointer layers.
+func implementationName(impl reflect.Type) string {
+	for impl.Kind() == reflect.Pointer {
+		impl = impl.Elem()
+	}
+	return impl.Name()
+}
+
+// isImplementationSegment reports whether the path segment denotes an implementation of an interface,
+// e.g. "['(EmailChannel)']", see [WithInterfaceImplementations].
+func isImplementationSegment(segment string) bool {
+	return strings.HasPrefix(segment, "['(") && strings.HasSuffix(segment, ")']")
+}
+
+// documentedImplementations returns the implementations registered for the interfaces documented in doc,
+// in the order of the interfaces' properties.
+func documentedImplementations(doc ObjectDoc, implementations map[reflect.Type][]reflect.Type) []reflect.Type {
+	var documented []reflect.Type
+	for _, property := range doc.Properties {
+		if !property.IsInterface {
+			continue
+		}
+		for iface, impls := range implementations {
+			if TypeInfoOf(iface) != property.TypeInfo {
+				continue
+			}
+			for _, impl := range impls {
+				if !slices.Contains(documented, impl) {
+					documented = append(documented, impl)
+				}
+			}
+		}
+	}
+	return documented
+}
@@ -0,0 +1,135 @@
+package govydoc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MarkdownOption configures [RenderMarkdown].
+type MarkdownOption func(options markdownOptions) markdownOptions
+
+type markdownOptions struct {
+	headingLevel   int
+	collapsingMaps bool
+}
+
+// WithMarkdownHeadingLevel returns an option that sets the heading level of the document title,
+// which is useful when embedding the document in another one. Defaults to 1.
+// Nested properties use consecutive levels, up to the maximum level of 6.
+func WithMarkdownHeadingLevel(level int) MarkdownOption {
+	return func(options markdownOptions) markdownOptions {
+		options.headingLevel = level
+		return options
+	}
+}
+
+// WithMarkdownCollapsedMaps returns an option that presents maps with scalar values, e.g. map[string]int,
+// as a single section, without separate sections for their keys and values.
+func WithMarkdownCollapsedMaps() MarkdownOption {
+	return func(options markdownOptions) markdownOptions {
+		options.collapsingMaps = true
+		return options
+	}
+}
+
+const maxMarkdownHeadingLevel = 6
+
+// RenderMarkdown renders o as a Markdown document with a section for every property.
+// Sections follow the properties' [PropertyDoc.ChildrenPaths], starting from the root property,
+// with every level of nesting rendered as a sub-section.
+// Properties which are not reachable from the root, e.g. due to filtering, are rendered after it.
+// Each section lists the property's type, documentation, default value, deprecation notice, and validation rules.
+func RenderMarkdown(o ObjectDoc, opts ...MarkdownOption) (string, error) {
+	options := markdownOptions{headingLevel: 1}
+	for _, opt := range opts {
+		options = opt(options)
+	}
+	if options.headingLevel < 1 || options.headingLevel > maxMarkdownHeadingLevel {
+		return "", fmt.Errorf("invalid Markdown heading level %d: level must be between 1 and %d",
+			options.headingLevel, maxMarkdownHeadingLevel)
+	}
+	if options.collapsingMaps {
+		o.Properties = collapsedProperties(o)
+	}
+
+	r := markdownRenderer{
+		doc:      o,
+		visited:  make(map[string]bool, len(o.Properties)),
+		sections: make([]string, 0, len(o.Properties)+1),
+	}
+	level := options.headingLevel
+	if o.Name != "" {
+		r.sections = append(r.sections, markdownHeading(level, o.Name))
+		level++
+	}
+	for _, property := range o.Properties {
+		if property.Path.IsRoot() {
+			r.renderProperty(property, level)
+		}
+	}
+	for _, property := range o.Properties {
+		if !r.visited[property.Path.String()] {
+			r.renderProperty(property, level+1)
+		}
+	}
+	return strings.Join(r.sections, "\n\n") + "\n", nil
+}
+
+type markdownRenderer struct {
+	doc      ObjectDoc
+	visited  map[string]bool
+	sections []string
+}
+
+func (r *markdownRenderer) renderProperty(property PropertyDoc, level int) {
+	path := property.Path.String()
+	if r.visited[path] {
+		return
+	}
+	r.visited[path] = true
+
+	heading := "`" + path + "`"
+	if property.Hidden {
+		heading += " (hidden)"
+	}
+	if property.Anchor != "" {
+		heading = `<a id="` + property.Anchor + `"></a>` + heading
+	}
+	r.sections = append(r.sections, markdownHeading(level, heading))
+	typeLine := "**Type:** `" + property.TypeInfo.Name + "`"
+	if property.TypeInfo.Kind != "" && property.TypeInfo.Kind != property.TypeInfo.Name {
+		typeLine += " (" + property.TypeInfo.Kind + ")"
+	}
+	r.sections = append(r.sections, typeLine)
+	if property.RecursiveRef != "" {
+		r.sections = append(r.sections, "**Recursive:** same as `"+property.RecursiveRef+"`")
+	}
+	if property.Truncated {
+		r.sections = append(r.sections, "**Truncated:** nested properties are not documented")
+	}
+	for _, doc := range []string{property.FieldDoc, property.TypeDoc} {
+		if doc != "" {
+			r.sections = append(r.sections, doc)
+		}
+	}
+	if property.DefaultValue != "" {
+		r.sections = append(r.sections, "**Default:** `"+property.DefaultValue+"`")
+	}
+	if isDeprecated(property) {
+		r.sections = append(r.sections, "**Deprecated:** "+property.DeprecatedDoc)
+	}
+	if property.TypeDeprecatedDoc != "" {
+		r.sections = append(r.sections, "**Deprecated type:** "+property.TypeDeprecatedDoc)
+	}
+	if rules := ruleList(property); len(rules) > 0 {
+		r.sections = append(r.sections, "**Rules:**\n\n- "+strings.Join(rules, "\n- "))
+	}
+
+	for _, child := range childrenOf(r.doc, property) {
+		r.renderProperty(child, level+1)
+	}
+}
+
+func markdownHeading(level int, text string) string {
+	return strings.Repeat("#", min(level, maxMarkdownHeadingLevel)) + " " + text
+}
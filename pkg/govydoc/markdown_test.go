@@ -0,0 +1,143 @@
+package govydoc
+
+import (
+	"testing"
+
+	"github.com/nobl9/govy/pkg/govy"
+	"github.com/nobl9/govy/pkg/jsonpath"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nieomylnieja/govydoc/internal/testmodels"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	t.Parallel()
+
+	doc := ObjectDoc{
+		Name: "Teacher",
+		Properties: []PropertyDoc{
+			{
+				PropertyPlan: govy.PropertyPlan{
+					Path:     jsonpath.Parse("$"),
+					TypeInfo: govy.TypeInfo{Name: "Teacher", Kind: "struct"},
+				},
+				TypeDoc:       "Teacher teaches students.",
+				ChildrenPaths: []string{"$.name", "$.students"},
+			},
+			{
+				PropertyPlan: govy.PropertyPlan{
+					Path:     jsonpath.Parse("$.name"),
+					TypeInfo: govy.TypeInfo{Name: "string", Kind: "string"},
+					Rules: []govy.RulePlan{
+						{Description: "must be equal to 'John'"},
+						{Description: "property is forbidden", Conditions: []string{"when above 30"}},
+					},
+				},
+				FieldDoc: "Name is the name of the teacher.",
+			},
+			{
+				PropertyPlan: govy.PropertyPlan{
+					Path:     jsonpath.Parse("$.students"),
+					TypeInfo: govy.TypeInfo{Name: "[]Student", Kind: "[]struct"},
+				},
+				ChildrenPaths: []string{"$.students.oldName"},
+			},
+			{
+				PropertyPlan: govy.PropertyPlan{
+					Path:     jsonpath.Parse("$.students.oldName"),
+					TypeInfo: govy.TypeInfo{Name: "string", Kind: "string"},
+				},
+				DeprecatedDoc: "Use name instead.",
+			},
+			{
+				PropertyPlan: govy.PropertyPlan{
+					Path:     jsonpath.Parse("$.orphan.age"),
+					TypeInfo: govy.TypeInfo{Name: "int", Kind: "int"},
+				},
+				DefaultValue: "30",
+				Default:      int64(30),
+			},
+		},
+	}
+
+	markdown, err := RenderMarkdown(doc)
+
+	require.NoError(t, err)
+	assert.Equal(t, "# Teacher\n\n"+
+		"## `$`\n\n"+
+		"**Type:** `Teacher` (struct)\n\n"+
+		"Teacher teaches students.\n\n"+
+		"### `$.name`\n\n"+
+		"**Type:** `string`\n\n"+
+		"Name is the name of the teacher.\n\n"+
+		"**Rules:**\n\n"+
+		"- must be equal to 'John'\n"+
+		"- property is forbidden (when above 30)\n\n"+
+		"### `$.students`\n\n"+
+		"**Type:** `[]Student` ([]struct)\n\n"+
+		"#### `$.students.oldName`\n\n"+
+		"**Type:** `string`\n\n"+
+		"**Deprecated:** Use name instead.\n\n"+
+		"### `$.orphan.age`\n\n"+
+		"**Type:** `int`\n\n"+
+		"**Default:** `30`\n", markdown)
+}
+
+func TestRenderMarkdown_Options(t *testing.T) {
+	doc, err := Generate(govy.New[testmodels.MapStruct]().WithName("MapStruct"))
+	require.NoError(t, err)
+
+	t.Run("heading level", func(t *testing.T) {
+		markdown, err := RenderMarkdown(doc, WithMarkdownHeadingLevel(5))
+		require.NoError(t, err)
+		assert.Contains(t, markdown, "##### MapStruct\n")
+		assert.Contains(t, markdown, "###### `$`\n")
+		assert.Contains(t, markdown, "###### `$.data`\n")
+	})
+
+	t.Run("invalid heading level", func(t *testing.T) {
+		_, err := RenderMarkdown(doc, WithMarkdownHeadingLevel(7))
+		require.EqualError(t, err, "invalid Markdown heading level 7: level must be between 1 and 6")
+	})
+
+	t.Run("collapsed maps", func(t *testing.T) {
+		markdown, err := RenderMarkdown(doc)
+		require.NoError(t, err)
+		assert.Contains(t, markdown, "`$.data.*`")
+
+		markdown, err = RenderMarkdown(doc, WithMarkdownCollapsedMaps())
+		require.NoError(t, err)
+		assert.Contains(t, markdown, "### `$.data`\n\n**Type:** `map[string]int`\n")
+		assert.NotContains(t, markdown, "`$.data.*`")
+		assert.NotContains(t, markdown, "`$.data.*~`")
+	})
+
+	t.Run("generated document", func(t *testing.T) {
+		teacherDoc, err := Generate(govy.New[testmodels.Teacher]().WithName("Teacher"))
+		require.NoError(t, err)
+		markdown, err := RenderMarkdown(teacherDoc)
+		require.NoError(t, err)
+		assert.Contains(t, markdown,
+			"#### `$.students[*].oldName`\n\n**Type:** `string`\n\n**Deprecated:** Use Name instead.\n")
+		assert.Contains(t, markdown, "\n\n**Deprecated type:** Use Teacher instead.\n")
+	})
+
+	t.Run("hidden property", func(t *testing.T) {
+		credentialsDoc, err := Generate(govy.New[testmodels.Credentials]().WithName("Credentials"), WithIncludeHidden())
+		require.NoError(t, err)
+		markdown, err := RenderMarkdown(credentialsDoc)
+		require.NoError(t, err)
+		assert.Contains(t, markdown, "## `$.user`\n")
+		assert.Contains(t, markdown, "## `$.Password` (hidden)\n")
+		assert.Contains(t, markdown, "### `$.Session.id` (hidden)\n")
+	})
+
+	t.Run("recursive type", func(t *testing.T) {
+		listDoc, err := Generate(govy.New[testmodels.ListNode]().WithName("ListNode"))
+		require.NoError(t, err)
+		markdown, err := RenderMarkdown(listDoc)
+		require.NoError(t, err)
+		assert.Contains(t, markdown, "### `$.next`\n\n**Type:** `ListNode` (struct)\n\n**Recursive:** same as `$`\n")
+	})
+}
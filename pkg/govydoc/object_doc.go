@@ -1,42 +1,348 @@
 package govydoc
 
 import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
 	"reflect"
+	"slices"
 	"strings"
 
+	"github.com/nobl9/govy/pkg/govy"
 	"github.com/nobl9/govy/pkg/jsonpath"
 )
 
-func generateObjectDoc(goType reflect.Type) ObjectDoc {
+func generateObjectDoc(goType reflect.Type, options generateOptions) (ObjectDoc, error) {
 	for goType.Kind() == reflect.Pointer {
 		goType = goType.Elem()
 	}
-	mapper := newObjectMapper()
-	mapper.mapType(goType, jsonpath.Parse("$"))
+	properties, err := newObjectMapper(options).Map(goType, jsonpath.Parse("$"))
+	if err != nil {
+		return ObjectDoc{}, err
+	}
+	return ObjectDoc{Name: goType.Name(), Properties: properties}, nil
+}
 
-	objectDoc := ObjectDoc{
-		Properties: mapper.properties,
+// Validate checks if the properties form a valid tree,
+// that is, if every property, except for the root and its immediate children,
+// is listed in [PropertyDoc.ChildrenPaths] of another property, so that it can be reached from the root.
+// The listing property does not have to be its parent, e.g. [WithTypeKindFilter] and [WithIncludedPaths]
+// omit parents, whose children are then listed by the nearest documented ancestor.
+// Orphaned properties are usually the result of filtering out their parent with [WithFilteredPaths].
+func (o ObjectDoc) Validate() error {
+	listed := make(map[string]struct{}, len(o.Properties))
+	for _, property := range o.Properties {
+		for _, childPath := range property.ChildrenPaths {
+			if childPath != property.Path.String() {
+				listed[childPath] = struct{}{}
+			}
+		}
+	}
+	var errs []error
+	for _, property := range o.Properties {
+		parent, ok := parentPath(property.Path.String())
+		if !ok || parent == "$" {
+			continue
+		}
+		if _, found := listed[property.Path.String()]; !found {
+			errs = append(errs, fmt.Errorf("property %s is not listed among the children of any property", property.Path))
+		}
 	}
-	for i, property := range objectDoc.Properties {
-		childrenPaths := findPropertyChildrenPaths(property.Path, objectDoc.Properties)
-		property.ChildrenPaths = childrenPaths
-		objectDoc.Properties[i] = property
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return fmt.Errorf("found %d orphaned properties: %w", len(errs), errors.Join(errs...))
 	}
-	return objectDoc
 }
 
-func findPropertyChildrenPaths(parent jsonpath.Path, properties []PropertyDoc) []string {
-	childrenPaths := make([]string, 0, len(properties))
-	parentString := parent.String()
-	for _, property := range properties {
-		childRelativePath, found := strings.CutPrefix(property.Path.String(), parentString+".")
-		if !found {
-			continue
+// MaxDepth returns the nesting depth of the deepest property, where the root property has a depth of 0
+// and every named segment of a property path, e.g. ".address" or "['a.b']", adds one level.
+// Slice elements ("[*]"), map keys (".*~"), and map values (".*") do not add a level,
+// as they are documented as a part of their slice or map, e.g. "$.students[*].name" has a depth of 2.
+func (o ObjectDoc) MaxDepth() int {
+	maxDepth := 0
+	for _, property := range o.Properties {
+		maxDepth = max(maxDepth, pathDepth(property.Path.String()))
+	}
+	return maxDepth
+}
+
+// pathDepth returns the number of named segments in the path, see [ObjectDoc.MaxDepth].
+func pathDepth(path string) int {
+	depth := 0
+	for {
+		parent, ok := parentPath(path)
+		if !ok {
+			return depth
+		}
+		if !isWildcardSegment(path[len(parent):]) {
+			depth++
 		}
-		if strings.Contains(childRelativePath, ".") {
+		path = parent
+	}
+}
+
+// pathRole returns the [PathRole] of the path's last segment.
+func pathRole(path string) PathRole {
+	parent, ok := parentPath(path)
+	if !ok {
+		return PathRoleRoot
+	}
+	switch segment := path[len(parent):]; {
+	case segment == ".*~":
+		return PathRoleMapKey
+	case segment == ".*":
+		return PathRoleMapValue
+	case isImplementationSegment(segment):
+		return PathRoleImplementation
+	case isWildcardSegment(segment):
+		return PathRoleSliceItem
+	default:
+		return PathRoleField
+	}
+}
+
+// isWildcardSegment reports whether the path segment denotes a slice element, map key, or map value.
+func isWildcardSegment(segment string) bool {
+	switch {
+	case segment == ".*", segment == ".*~":
+		return true
+	default:
+		return strings.HasPrefix(segment, "[") && !strings.HasPrefix(segment, "['")
+	}
+}
+
+// sortProperties sorts properties by their paths, see [WithSortedPaths].
+func sortProperties(properties []PropertyDoc) {
+	slices.SortStableFunc(properties, func(a, b PropertyDoc) int {
+		return comparePaths(a.Path.String(), b.Path.String())
+	})
+}
+
+// comparePaths compares paths segment by segment, a path precedes the paths it is a prefix of.
+func comparePaths(a, b string) int {
+	return slices.CompareFunc(pathSegments(a), pathSegments(b), func(a, b string) int {
+		return cmp.Or(
+			cmp.Compare(segmentOrder(a), segmentOrder(b)),
+			strings.Compare(segmentName(a), segmentName(b)),
+		)
+	})
+}
+
+// segmentOrder orders struct fields before slice elements, map keys, and map values.
+func segmentOrder(segment string) int {
+	switch {
+	case !isWildcardSegment(segment):
+		return 0
+	case segment == ".*~":
+		return 2
+	case segment == ".*":
+		return 3
+	default:
+		return 1
+	}
+}
+
+// Clone returns a deep copy of o, which can be modified without affecting o.
+func (o ObjectDoc) Clone() ObjectDoc {
+	clone := o
+	if o.Properties != nil {
+		clone.Properties = make([]PropertyDoc, 0, len(o.Properties))
+		for _, property := range o.Properties {
+			clone.Properties = append(clone.Properties, property.clone())
+		}
+	}
+	if o.Examples != nil {
+		clone.Examples = make([]Example, 0, len(o.Examples))
+		for _, example := range o.Examples {
+			if example.Valid != nil {
+				valid := *example.Valid
+				example.Valid = &valid
+			}
+			example.Errors = slices.Clone(example.Errors)
+			clone.Examples = append(clone.Examples, example)
+		}
+	}
+	if o.UnionGroups != nil {
+		clone.UnionGroups = make([]UnionGroup, 0, len(o.UnionGroups))
+		for _, group := range o.UnionGroups {
+			group.Properties = slices.Clone(group.Properties)
+			clone.UnionGroups = append(clone.UnionGroups, group)
+		}
+	}
+	clone.Metadata = maps.Clone(o.Metadata)
+	clone.PlanWarnings = slices.Clone(o.PlanWarnings)
+	clone.DocWarnings = slices.Clone(o.DocWarnings)
+	clone.Plan = cloneValidatorPlan(o.Plan)
+	return clone
+}
+
+func (p PropertyDoc) clone() PropertyDoc {
+	p.PropertyPlan = clonePropertyPlan(p.PropertyPlan)
+	p.TypeDocBlocks = cloneDocBlocks(p.TypeDocBlocks)
+	p.FieldDocBlocks = cloneDocBlocks(p.FieldDocBlocks)
+	p.ChildrenPaths = slices.Clone(p.ChildrenPaths)
+	p.EnumValues = slices.Clone(p.EnumValues)
+	p.EnumConstants = slices.Clone(p.EnumConstants)
+	p.AllowedValues = slices.Clone(p.AllowedValues)
+	p.Methods = slices.Clone(p.Methods)
+	if p.Constraints != nil {
+		constraints := p.Constraints.clone()
+		p.Constraints = &constraints
+	}
+	p.RuleDocs = cloneRuleDocs(p.RuleDocs)
+	p.Conditions = slices.Clone(p.Conditions)
+	return p
+}
+
+func cloneValidatorPlan(plan *govy.ValidatorPlan) *govy.ValidatorPlan {
+	if plan == nil {
+		return nil
+	}
+	clone := &govy.ValidatorPlan{Name: plan.Name}
+	if plan.Properties != nil {
+		clone.Properties = make([]*govy.PropertyPlan, 0, len(plan.Properties))
+		for _, property := range plan.Properties {
+			propertyClone := clonePropertyPlan(*property)
+			clone.Properties = append(clone.Properties, &propertyClone)
+		}
+	}
+	return clone
+}
+
+func clonePropertyPlan(plan govy.PropertyPlan) govy.PropertyPlan {
+	plan.Examples = slices.Clone(plan.Examples)
+	plan.Values = slices.Clone(plan.Values)
+	if plan.Rules != nil {
+		rules := make([]govy.RulePlan, 0, len(plan.Rules))
+		for _, rule := range plan.Rules {
+			rule.Conditions = slices.Clone(rule.Conditions)
+			rule.Examples = slices.Clone(rule.Examples)
+			rules = append(rules, rule)
+		}
+		plan.Rules = rules
+	}
+	return plan
+}
+
+// Merge adds the properties of other to o, placing other's root property at prefix, e.g. "$.address".
+// It is useful for documenting types assembled from mixins which are documented separately.
+// The [ObjectDoc.UnionGroups] of other are moved under prefix as well,
+// while its name, documentation, examples, and plan are discarded.
+// The [PropertyDoc.ChildrenPaths] of other's properties are moved under prefix along with them,
+// and the path of other's root property is added to the children of the property at prefix's parent,
+// or, if it is not documented, e.g. omitted with [WithTypeKindFilter], of its nearest documented ancestor.
+// The [PropertyDoc.ChildrenPaths] of the other properties of o are kept as they are.
+// The [PropertyDoc.PathRole] of other's root property is set according to prefix.
+// An error is returned if prefix is not a valid JSON path or if any of the merged paths is already documented.
+func (o *ObjectDoc) Merge(prefix string, other ObjectDoc) error {
+	if !strings.HasPrefix(prefix, "$") {
+		return fmt.Errorf("invalid merge prefix %q: path must start with $", prefix)
+	}
+	if prefixPath := jsonpath.Parse(prefix); prefixPath.String() != prefix {
+		return fmt.Errorf("invalid merge prefix %q: path is parsed as %s", prefix, prefixPath)
+	}
+	movePath := func(path string) string {
+		return prefix + strings.TrimPrefix(path, "$")
+	}
+
+	paths := make(map[string]struct{}, len(o.Properties)+len(other.Properties))
+	for _, property := range o.Properties {
+		paths[property.Path.String()] = struct{}{}
+	}
+	merged := make([]PropertyDoc, 0, len(other.Properties))
+	for _, property := range other.Properties {
+		path := movePath(property.Path.String())
+		if _, found := paths[path]; found {
+			return fmt.Errorf("cannot merge property %s: property %s already exists", property.Path, path)
+		}
+		paths[path] = struct{}{}
+		if property.Path.IsRoot() {
+			property.PathRole = pathRole(path)
+		}
+		property.Path = jsonpath.Parse(path)
+		property.ChildrenPaths = slices.Clone(property.ChildrenPaths)
+		for i, childPath := range property.ChildrenPaths {
+			property.ChildrenPaths[i] = movePath(childPath)
+		}
+		merged = append(merged, property)
+	}
+	o.addChildPath(prefix)
+	o.Properties = append(o.Properties, merged...)
+
+	for _, group := range other.UnionGroups {
+		moved := UnionGroup{Path: movePath(group.Path)}
+		for _, path := range group.Properties {
+			moved.Properties = append(moved.Properties, movePath(path))
+		}
+		o.UnionGroups = append(o.UnionGroups, moved)
+	}
+	return nil
+}
+
+// addChildPath adds path to the [PropertyDoc.ChildrenPaths] of its nearest documented ancestor.
+func (o *ObjectDoc) addChildPath(path string) {
+	for parent, ok := parentPath(path); ok; parent, ok = parentPath(parent) {
+		i := slices.IndexFunc(o.Properties, func(property PropertyDoc) bool { return property.Path.String() == parent })
+		if i == -1 {
 			continue
 		}
-		childrenPaths = append(childrenPaths, parentString+"."+childRelativePath)
+		if !slices.Contains(o.Properties[i].ChildrenPaths, path) {
+			o.Properties[i].ChildrenPaths = append(slices.Clone(o.Properties[i].ChildrenPaths), path)
+		}
+		return
+	}
+}
+
+// parentPath returns the path without its last segment.
+// It returns false if the path has no parent, which is the case for the root path.
+func parentPath(path string) (string, bool) {
+	lastSegmentStart := -1
+	inQuotes := false
+	for i := 0; i < len(path); i++ {
+		switch path[i] {
+		case '\\':
+			i++
+		case '\'':
+			inQuotes = !inQuotes
+		case '.', '[':
+			if !inQuotes {
+				lastSegmentStart = i
+			}
+		}
+	}
+	if lastSegmentStart <= 0 {
+		return "", false
+	}
+	return path[:lastSegmentStart], true
+}
+
+// defaultArrayToken is the token used by govy to denote any slice element.
+const defaultArrayToken = "[*]"
+
+// validateArrayToken checks if token, when used in place of [defaultArrayToken],
+// produces paths which can be parsed back into the same [jsonpath.Path].
+func validateArrayToken(token string) error {
+	if !strings.HasPrefix(token, "[") || !strings.HasSuffix(token, "]") {
+		return fmt.Errorf("invalid array token %q: token must be enclosed in square brackets", token)
+	}
+	path := "$.items" + token + ".name"
+	if parsed := jsonpath.Parse(path).String(); parsed != path {
+		return fmt.Errorf("invalid array token %q: path %s is parsed as %s", token, path, parsed)
+	}
+	return nil
+}
+
+// replacePropertyArrayToken replaces [defaultArrayToken] with token in the property's path and its children paths.
+func replacePropertyArrayToken(property PropertyDoc, token string) PropertyDoc {
+	property.Path = jsonpath.Parse(strings.ReplaceAll(property.Path.String(), defaultArrayToken, token))
+	property.ChildrenPaths = slices.Clone(property.ChildrenPaths)
+	for i, childPath := range property.ChildrenPaths {
+		property.ChildrenPaths[i] = strings.ReplaceAll(childPath, defaultArrayToken, token)
 	}
-	return childrenPaths
+	return property
 }
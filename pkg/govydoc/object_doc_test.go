@@ -0,0 +1,396 @@
+package govydoc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nobl9/govy/pkg/govy"
+	"github.com/nobl9/govy/pkg/jsonpath"
+	"github.com/nobl9/govy/pkg/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nieomylnieja/govydoc/internal/testmodels"
+)
+
+func TestObjectDoc_Validate(t *testing.T) {
+	t.Parallel()
+
+	// property lists the path of a property followed by its children paths.
+	type property []string
+	tests := map[string]struct {
+		properties []property
+		expected   string
+	}{
+		"valid tree": {
+			properties: []property{
+				{"$", "$.name", "$.items"},
+				{"$.name"},
+				{"$.items", "$.items[*]"},
+				{"$.items[*]", "$.items[*].name"},
+				{"$.items[*].name"},
+			},
+		},
+		"root filtered": {
+			properties: []property{{"$.name"}, {"$.address"}},
+		},
+		"omitted parent": {
+			properties: []property{{"$", "$.items[*]"}, {"$.items[*]"}},
+		},
+		"orphaned property": {
+			properties: []property{{"$", "$.address"}, {"$.address.city"}},
+			expected:   "property $.address.city is not listed among the children of any property",
+		},
+		"orphaned slice element": {
+			properties: []property{{"$"}, {"$.items[*]"}},
+			expected:   "property $.items[*] is not listed among the children of any property",
+		},
+		"multiple orphaned properties": {
+			properties: []property{{"$"}, {"$.data.*~"}, {"$.data.*"}},
+			expected: "found 2 orphaned properties: " +
+				"property $.data.*~ is not listed among the children of any property\n" +
+				"property $.data.* is not listed among the children of any property",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			doc := ObjectDoc{}
+			for _, p := range test.properties {
+				doc.Properties = append(doc.Properties, PropertyDoc{
+					PropertyPlan:  govy.PropertyPlan{Path: jsonpath.Parse(p[0])},
+					ChildrenPaths: p[1:],
+				})
+			}
+			err := doc.Validate()
+			if test.expected == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestObjectDoc_Merge(t *testing.T) {
+	personDoc, err := Generate(
+		govy.New[testmodels.Person]().WithName("Person"),
+		WithFilteredPaths("$.address", "$.address.city", "$.address.state"),
+	)
+	require.NoError(t, err)
+	addressDoc, err := Generate(govy.New(
+		govy.For(func(a testmodels.Address) string { return a.City }).
+			WithName("city").
+			Rules(rules.StringNotEmpty()),
+	).WithName("Address"))
+	require.NoError(t, err)
+
+	err = personDoc.Merge("$.address", addressDoc)
+
+	require.NoError(t, err)
+	require.NoError(t, personDoc.Validate())
+	assert.Equal(t, []string{"$", "$.name", "$.address", "$.address.city", "$.address.state"}, propertyPaths(personDoc))
+	assert.Equal(t, []string{"$.name", "$.address"}, findProperty(t, personDoc, "$").ChildrenPaths)
+	address := findProperty(t, personDoc, "$.address")
+	assert.Equal(t, "Address represents a physical address.", address.TypeDoc)
+	assert.Equal(t, PathRoleField, address.PathRole)
+	assert.Equal(t, []string{"$.address.city", "$.address.state"}, address.ChildrenPaths)
+	city := findProperty(t, personDoc, "$.address.city")
+	assert.Equal(t, "City is the name of the city.", city.FieldDoc)
+	require.Len(t, city.Rules, 1)
+	assert.Equal(t, "string must not be empty", city.Rules[0].Description)
+
+	t.Run("kind filtered", func(t *testing.T) {
+		teacherDoc, err := Generate(govy.New[testmodels.Teacher](), WithTypeKindFilter("struct", "string"))
+		require.NoError(t, err)
+		rootChildren := findProperty(t, teacherDoc, "$").ChildrenPaths
+		require.Contains(t, rootChildren, "$.students[*]")
+
+		err = teacherDoc.Merge("$.students[*].address", addressDoc)
+
+		require.NoError(t, err)
+		assert.Equal(t, rootChildren, findProperty(t, teacherDoc, "$").ChildrenPaths)
+		assert.Equal(t,
+			[]string{"$.students[*].address.city", "$.students[*].address.state"},
+			findProperty(t, teacherDoc, "$.students[*].address").ChildrenPaths)
+		assert.Contains(t, findProperty(t, teacherDoc, "$.students[*]").ChildrenPaths, "$.students[*].address")
+	})
+	t.Run("kind filtered parent", func(t *testing.T) {
+		teacherDoc, err := Generate(govy.New[testmodels.Teacher](), WithTypeKindFilter("struct", "string"))
+		require.NoError(t, err)
+
+		err = teacherDoc.Merge("$.mentors[*]", addressDoc)
+
+		require.NoError(t, err)
+		assert.Contains(t, findProperty(t, teacherDoc, "$").ChildrenPaths, "$.mentors[*]")
+	})
+	t.Run("conflict", func(t *testing.T) {
+		err := personDoc.Merge("$.address", addressDoc)
+		require.EqualError(t, err, "cannot merge property $: property $.address already exists")
+	})
+	t.Run("invalid prefix", func(t *testing.T) {
+		err := personDoc.Merge("address", addressDoc)
+		require.EqualError(t, err, `invalid merge prefix "address": path must start with $`)
+		err = personDoc.Merge("$[i]", addressDoc)
+		require.EqualError(t, err, `invalid merge prefix "$[i]": path is parsed as $.i`)
+	})
+}
+
+func TestObjectDoc_Clone(t *testing.T) {
+	t.Parallel()
+
+	valid := true
+	original := ObjectDoc{
+		Name: "Teacher",
+		Properties: []PropertyDoc{{
+			PropertyPlan: govy.PropertyPlan{
+				Path:   jsonpath.Parse("$.name"),
+				Values: []string{"John"},
+				Rules:  []govy.RulePlan{{Description: "must be equal to 'John'", Conditions: []string{"always"}}},
+			},
+			TypeDocBlocks: []DocBlock{{Kind: DocBlockList, Items: []string{"item"}}},
+			ChildrenPaths: []string{"$.name.first"},
+			EnumValues:    []string{"John"},
+			AllowedValues: []string{"John"},
+			Constraints:   &Constraints{MinLen: ptr(1), Enum: []string{"John"}},
+			Conditions:    []string{"always"},
+		}},
+		Examples:     []Example{{Name: "valid", Valid: &valid, Errors: []string{"none"}}},
+		UnionGroups:  []UnionGroup{{Path: "$", Properties: []string{"$.a", "$.b"}}},
+		Metadata:     map[string]string{"owner": "platform"},
+		PlanWarnings: []string{"warning"},
+		Plan: &govy.ValidatorPlan{Properties: []*govy.PropertyPlan{{
+			Path:  jsonpath.Parse("$.name"),
+			Rules: []govy.RulePlan{{Description: "must be equal to 'John'", Conditions: []string{"always"}}},
+		}}},
+	}
+	expected := mustMarshalJSON(t, original)
+	expectedPlan := mustMarshalJSON(t, original.Plan)
+
+	clone := original.Clone()
+	property := &clone.Properties[0]
+	property.Values[0] = "Jane"
+	property.Rules[0].Conditions[0] = "never"
+	property.TypeDocBlocks[0].Items[0] = "changed"
+	property.ChildrenPaths[0] = "$.name.last"
+	property.EnumValues[0] = "Jane"
+	property.AllowedValues[0] = "Jane"
+	*property.Constraints.MinLen = 2
+	property.Constraints.Enum[0] = "Jane"
+	property.Conditions[0] = "never"
+	*clone.Examples[0].Valid = false
+	clone.Examples[0].Errors[0] = "changed"
+	clone.UnionGroups[0].Properties[0] = "$.c"
+	clone.Metadata["owner"] = "changed"
+	clone.PlanWarnings[0] = "changed"
+	clone.Plan.Properties[0].Rules[0].Conditions[0] = "never"
+	clone.Plan.Properties[0].Path = jsonpath.Parse("$.other")
+
+	assert.JSONEq(t, expected, mustMarshalJSON(t, original))
+	assert.JSONEq(t, expectedPlan, mustMarshalJSON(t, original.Plan))
+	assert.Equal(t, ObjectDoc{}, ObjectDoc{}.Clone())
+}
+
+func TestObjectDoc_MaxDepth(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		generate func() (ObjectDoc, error)
+		expected int
+	}{
+		"flat struct": {
+			generate: func() (ObjectDoc, error) { return Generate(govy.New[testmodels.SimpleStruct]()) },
+			expected: 1,
+		},
+		"struct with slice of structs": {
+			generate: func() (ObjectDoc, error) { return Generate(govy.New[testmodels.Teacher]()) },
+			expected: 2,
+		},
+		"nested struct": {
+			generate: func() (ObjectDoc, error) { return Generate(govy.New[testmodels.Person]()) },
+			expected: 2,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			doc, err := test.generate()
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, doc.MaxDepth())
+		})
+	}
+	assert.Equal(t, 0, ObjectDoc{}.MaxDepth())
+}
+
+func Test_pathDepth(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		path     string
+		expected int
+	}{
+		"root":               {path: "$", expected: 0},
+		"root field":         {path: "$.name", expected: 1},
+		"nested field":       {path: "$.address.city", expected: 2},
+		"slice element":      {path: "$.items[*]", expected: 1},
+		"slice element name": {path: "$.students[*].name", expected: 2},
+		"map key":            {path: "$.data.*~", expected: 1},
+		"map value field":    {path: "$.data.*.city", expected: 2},
+		"quoted name":        {path: "$['a.b'].c", expected: 2},
+		"root slice item":    {path: "$[*]", expected: 0},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, pathDepth(test.path))
+		})
+	}
+}
+
+func Test_parentPath(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		path     string
+		expected string
+	}{
+		"root":            {path: "$"},
+		"root field":      {path: "$.name", expected: "$"},
+		"nested field":    {path: "$.address.city", expected: "$.address"},
+		"slice element":   {path: "$.items[*]", expected: "$.items"},
+		"map key":         {path: "$.data.*~", expected: "$.data"},
+		"map value":       {path: "$.data.*", expected: "$.data"},
+		"quoted name":     {path: "$['a.b']", expected: "$"},
+		"quoted parent":   {path: "$['a.b'].c", expected: "$['a.b']"},
+		"escaped quote":   {path: `$['a\'.b'].c`, expected: `$['a\'.b']`},
+		"root slice item": {path: "$[*]", expected: "$"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			parent, ok := parentPath(test.path)
+			assert.Equal(t, test.expected != "", ok)
+			assert.Equal(t, test.expected, parent)
+		})
+	}
+}
+
+func Test_pathRole(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		path     string
+		expected PathRole
+	}{
+		"root":          {path: "$", expected: PathRoleRoot},
+		"field":         {path: "$.name", expected: PathRoleField},
+		"quoted field":  {path: "$['a.b']", expected: PathRoleField},
+		"slice element": {path: "$.items[*]", expected: PathRoleSliceItem},
+		"custom token":  {path: "$.items[]", expected: PathRoleSliceItem},
+		"map key":       {path: "$.data.*~", expected: PathRoleMapKey},
+		"map value":     {path: "$.data.*", expected: PathRoleMapValue},
+		"implementation": {
+			path:     "$.channel['(EmailChannel)']",
+			expected: PathRoleImplementation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, pathRole(test.path))
+		})
+	}
+}
+
+func Test_comparePaths(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		a, b     string
+		expected int
+	}{
+		"equal":                      {a: "$.a", b: "$.a", expected: 0},
+		"root first":                 {a: "$", b: "$.a", expected: -1},
+		"parent before child":        {a: "$.a", b: "$.a.b", expected: -1},
+		"child before next sibling":  {a: "$.a.b", b: "$.b", expected: -1},
+		"names":                      {a: "$.b", b: "$.a", expected: 1},
+		"quoted name":                {a: "$['a.b']", b: "$.b", expected: -1},
+		"field before slice element": {a: "$.a.b", b: "$.a[*]", expected: -1},
+		"map key before value":       {a: "$.a.*~", b: "$.a.*", expected: -1},
+		"map value descendants":      {a: "$.a.*.b", b: "$.a.*~", expected: 1},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, comparePaths(test.a, test.b))
+		})
+	}
+}
+
+func TestGenerate_ChildrenPaths(t *testing.T) {
+	t.Run("ListStruct", func(t *testing.T) {
+		doc, err := Generate(govy.New[testmodels.ListStruct]().WithName("ListStruct"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"$.items"}, findProperty(t, doc, "$").ChildrenPaths)
+		assert.Equal(t, []string{"$.items[*]"}, findProperty(t, doc, "$.items").ChildrenPaths)
+		assert.Empty(t, findProperty(t, doc, "$.items[*]").ChildrenPaths)
+	})
+
+	t.Run("MapStruct", func(t *testing.T) {
+		doc, err := Generate(govy.New[testmodels.MapStruct]().WithName("MapStruct"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"$.data"}, findProperty(t, doc, "$").ChildrenPaths)
+		assert.Equal(t, []string{"$.data.*~", "$.data.*"}, findProperty(t, doc, "$.data").ChildrenPaths)
+		assert.Empty(t, findProperty(t, doc, "$.data.*").ChildrenPaths)
+	})
+
+	t.Run("slice of structs", func(t *testing.T) {
+		doc, err := Generate(govy.New[testmodels.Teacher]().WithName("Teacher"))
+		require.NoError(t, err)
+		assert.NotContains(t, findProperty(t, doc, "$").ChildrenPaths, "$.students[*]")
+		assert.Equal(t, []string{"$.students[*]"}, findProperty(t, doc, "$.students").ChildrenPaths)
+		assert.Equal(t,
+			[]string{"$.students[*].age", "$.students[*].name", "$.students[*].oldName"},
+			findProperty(t, doc, "$.students[*]").ChildrenPaths)
+	})
+}
+
+// panickingType panics when its kind is inspected.
+type panickingType struct {
+	reflect.Type
+}
+
+func (panickingType) Kind() reflect.Kind { panic("unsupported type") }
+
+// fieldTypeOverride replaces the type of the first struct field.
+type fieldTypeOverride struct {
+	reflect.Type
+	fieldType reflect.Type
+}
+
+func (f fieldTypeOverride) Field(i int) reflect.StructField {
+	field := f.Type.Field(i)
+	if i == 0 {
+		field.Type = f.fieldType
+	}
+	return field
+}
+
+func Test_generateObjectDoc_RecoversPanic(t *testing.T) {
+	t.Parallel()
+
+	typ := fieldTypeOverride{
+		Type:      reflect.TypeFor[testmodels.SimpleStruct](),
+		fieldType: panickingType{reflect.TypeFor[string]()},
+	}
+
+	_, err := generateObjectDoc(typ, generateOptions{tagName: "json"})
+
+	require.EqualError(t, err, "panic while mapping string at $.value: unsupported type")
+}
@@ -1,7 +1,10 @@
 package govydoc
 
 import (
+	"fmt"
+	"go/token"
 	"reflect"
+	"slices"
 	"strings"
 
 	"github.com/nobl9/govy/pkg/govy"
@@ -11,45 +14,433 @@ import (
 )
 
 type objectMapper struct {
-	properties []PropertyDoc
+	options generateOptions
+	// yield receives the mapped properties, every property is yielded before its children.
+	// Mapping stops once it returns false.
+	yield   func(PropertyDoc) bool
+	stopped bool
+	// yielding is true while yield is called, so that its panics are not reported as mapping panics.
+	yielding bool
+	// ancestors holds the types being mapped on the current path, used to detect recursive types.
+	ancestors []mappedType
+	// hidden is true while mapping a hidden field and its descendants, see [WithIncludeHidden].
+	hidden bool
 }
 
-func newObjectMapper() *objectMapper {
-	return &objectMapper{}
+type mappedType struct {
+	typ  reflect.Type
+	path jsonpath.Path
 }
 
-func (o *objectMapper) mapType(typ reflect.Type, path jsonpath.Path) {
+// mappedProperty is a property to be mapped, along with the struct field it is declared with, if any.
+type mappedProperty struct {
+	typ  reflect.Type
+	path jsonpath.Path
+	role PathRole
+	// structType and field are set for struct fields.
+	structType reflect.Type
+	field      *reflect.StructField
+	// hidden is true for struct fields which are not encoded.
+	hidden bool
+}
+
+func newObjectMapper(options generateOptions) *objectMapper {
+	return &objectMapper{options: options}
+}
+
+// mappingPanic annotates a panic raised while mapping a type with the path and type being mapped.
+type mappingPanic struct {
+	path  jsonpath.Path
+	typ   reflect.Type
+	value any
+}
+
+func (m *mappingPanic) Error() string {
+	return fmt.Sprintf("panic while mapping %s at %s: %v", m.typ, m.path, m.value)
+}
+
+// Map maps typ and its nested types to properties starting at path and returns them.
+// Panics raised during mapping are recovered and returned as errors
+// which point to the innermost path and type which were being mapped.
+func (o *objectMapper) Map(typ reflect.Type, path jsonpath.Path) ([]PropertyDoc, error) {
+	var properties []PropertyDoc
+	err := o.Walk(typ, path, func(property PropertyDoc) bool {
+		properties = append(properties, property)
+		return true
+	})
+	return properties, err
+}
+
+// Walk works like [objectMapper.Map], but passes the properties to yield one at a time, as they are mapped,
+// instead of collecting them. Walking stops once yield returns false.
+// Panics raised by yield are not recovered.
+func (o *objectMapper) Walk(typ reflect.Type, path jsonpath.Path, yield func(PropertyDoc) bool) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			mp, ok := r.(*mappingPanic)
+			if !ok || o.yielding {
+				panic(r)
+			}
+			err = mp
+		}
+	}()
+	o.yield = yield
+	role := PathRoleField
+	if path.IsRoot() {
+		role = PathRoleRoot
+	}
+	o.mapType(mappedProperty{typ: typ, path: path, role: role})
+	return nil
+}
+
+// emit passes the property to yield, unless mapping has already been stopped.
+func (o *objectMapper) emit(doc PropertyDoc) {
+	if o.stopped {
+		return
+	}
+	o.yielding = true
+	o.stopped = !o.yield(doc)
+	o.yielding = false
+}
+
+func (o *objectMapper) mapType(property mappedProperty) {
+	typ, path := property.typ, property.path
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(*mappingPanic); ok || o.yielding {
+				panic(r)
+			}
+			panic(&mappingPanic{path: path, typ: typ, value: r})
+		}
+	}()
+	if o.stopped {
+		return
+	}
+	if property.hidden && !o.hidden {
+		o.hidden = true
+		defer func() { o.hidden = false }()
+	}
+
+	isPointer := typ.Kind() == reflect.Pointer
 	for typ.Kind() == reflect.Pointer {
 		typ = typ.Elem()
 	}
 
 	doc := PropertyDoc{}
 	doc.Path = path
-	doc = setTypeInfo(doc, typ)
-	o.properties = append(o.properties, doc)
+	doc.PathRole = property.role
+	doc.Hidden = o.hidden
+	doc = o.setTypeInfo(doc, typ)
+	doc = o.setFieldInfo(doc, property)
+	// Nullability is further restricted by the validation rules, see restrictNullability.
+	isCollection := typ.Kind() == reflect.Slice || typ.Kind() == reflect.Map
+	doc.AllowsNull = isPointer || isCollection
+	doc.AllowsEmpty = isCollection
+	doc.IsInterface = typ.Kind() == reflect.Interface
+	if o.options.documenterInterface {
+		doc.TypeDoc = documenterDescription(typ)
+	}
+	switch o.leafReason(typ, path) {
+	case leafScalarType:
+		doc.TypeDoc = o.options.scalarTypes[typ].description
+		o.emit(doc)
+		return
+	case leafRecursive:
+		ancestorPath, _ := o.ancestorPath(typ)
+		doc.RecursiveRef = ancestorPath.String()
+		o.emit(doc)
+		return
+	case leafTruncated:
+		doc.Truncated = true
+		o.emit(doc)
+		return
+	case leafScalarInterface, leafUnexportedType:
+		o.emit(doc)
+		return
+	case notLeaf:
+	}
+
+	o.ancestors = append(o.ancestors, mappedType{typ: typ, path: path})
+	defer func() { o.ancestors = o.ancestors[:len(o.ancestors)-1] }()
+	children := o.children(typ, path)
+	doc.ChildrenPaths = o.documentedPaths(children)
+	o.emit(doc)
 
+	for _, child := range children {
+		o.mapType(child)
+	}
+}
+
+// leafReason tells why a property is mapped without its nested properties.
+type leafReason int
+
+const (
+	notLeaf leafReason = iota
+	// leafScalarType is a type registered with [WithScalarType].
+	leafScalarType
+	// leafScalarInterface is a type implementing an interface registered with [WithScalarInterface].
+	leafScalarInterface
+	// leafRecursive is a type already being mapped by one of the ancestors, see [PropertyDoc.RecursiveRef].
+	leafRecursive
+	// leafUnexportedType is an unexported named type, see [WithExportedTypesOnly].
+	leafUnexportedType
+	// leafTruncated is a type whose nested properties are too deep, see [PropertyDoc.Truncated].
+	leafTruncated
+)
+
+// leafReason returns the reason the property of typ at path is mapped without its nested properties,
+// or notLeaf if its nested properties are mapped.
+func (o *objectMapper) leafReason(typ reflect.Type, path jsonpath.Path) leafReason {
+	if _, ok := o.options.scalarTypes[typ]; ok {
+		return leafScalarType
+	}
+	if _, ok := scalarInterfaceKind(typ, o.options.scalarInterfaces); ok {
+		return leafScalarInterface
+	}
+	if _, ok := o.ancestorPath(typ); ok {
+		return leafRecursive
+	}
+	if o.options.exportedTypesOnly && !path.IsRoot() && typ.Name() != "" && !token.IsExported(typ.Name()) {
+		return leafUnexportedType
+	}
+	if o.isBeyondMaxDepth(path) && o.hasNestedProperties(typ) {
+		return leafTruncated
+	}
+	return notLeaf
+}
+
+// documentedPaths returns the paths of the properties which are documented, see [WithTypeKindFilter],
+// to be listed in [PropertyDoc.ChildrenPaths] of their parent.
+// Properties of other kinds are replaced with their nearest documented descendants,
+// so that these remain reachable from their nearest documented ancestor.
+// The types of the properties' parents must be on top of the ancestors.
+func (o *objectMapper) documentedPaths(properties []mappedProperty) []string {
+	paths := make([]string, 0, len(properties))
+	add := func(path string) {
+		// Guard against properties documented more than once, e.g. through embedding promotion.
+		if !slices.Contains(paths, path) {
+			paths = append(paths, path)
+		}
+	}
+	for _, property := range properties {
+		if o.isDocumentedKind(property.typ) {
+			add(property.path.String())
+			continue
+		}
+		typ := property.typ
+		for typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+		if o.leafReason(typ, property.path) != notLeaf {
+			continue
+		}
+		o.ancestors = append(o.ancestors, mappedType{typ: typ, path: property.path})
+		for _, path := range o.documentedPaths(o.children(typ, property.path)) {
+			add(path)
+		}
+		o.ancestors = o.ancestors[:len(o.ancestors)-1]
+	}
+	return paths
+}
+
+// children returns the nested properties of a property of typ at path, in the order they are mapped.
+func (o *objectMapper) children(typ reflect.Type, path jsonpath.Path) []mappedProperty {
 	switch typ.Kind() {
 	case reflect.Struct:
+		var children []mappedProperty
 		for _, field := range reflect.VisibleFields(typ) {
-			if !field.IsExported() {
+			if !o.isPromotedField(typ, field) {
 				continue
 			}
-			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
-			if name == "" || name == "-" {
-				continue
+			if name, hidden := o.structFieldName(field); name != "" {
+				children = append(children, mappedProperty{
+					typ:        field.Type,
+					path:       path.Name(name),
+					role:       PathRoleField,
+					structType: typ,
+					field:      &field,
+					hidden:     hidden,
+				})
 			}
-			o.mapType(field.Type, path.Name(name))
 		}
-	case reflect.Slice:
-		o.mapType(typ.Elem(), path.IndexWildcard())
+		return children
+	case reflect.Slice, reflect.Array:
+		return []mappedProperty{{typ: typ.Elem(), path: path.IndexWildcard(), role: PathRoleSliceItem}}
 	case reflect.Map:
-		o.mapType(typ.Key(), path.KeyWildcard())
-		o.mapType(typ.Elem(), path.ValueWildcard())
+		children := make([]mappedProperty, 0, 2)
+		if !o.options.withoutMapKeys {
+			children = append(children, mappedProperty{typ: typ.Key(), path: path.KeyWildcard(), role: PathRoleMapKey})
+		}
+		return append(children, mappedProperty{typ: typ.Elem(), path: path.ValueWildcard(), role: PathRoleMapValue})
+	case reflect.Interface:
+		impls := o.options.implementations[typ]
+		children := make([]mappedProperty, 0, len(impls))
+		for _, impl := range impls {
+			children = append(children, mappedProperty{
+				typ:  impl,
+				path: path.Name("(" + implementationName(impl) + ")"),
+				role: PathRoleImplementation,
+			})
+		}
+		return children
 	default:
+		return nil
 	}
 }
 
-func setTypeInfo(doc PropertyDoc, typ reflect.Type) PropertyDoc {
-	doc.TypeInfo = govy.TypeInfo(typeinfo.Get(typ))
+// isDocumentedKind reports whether properties of typ are documented with the kinds set with [WithTypeKindFilter].
+// Properties of other kinds are still mapped, as their descendants can be documented,
+// but they are not listed among the children of their parents, see [objectMapper.documentedPaths].
+func (o *objectMapper) isDocumentedKind(typ reflect.Type) bool {
+	return len(o.options.typeKinds) == 0 || slices.Contains(o.options.typeKinds, o.typeInfo(typ).Kind)
+}
+
+// ancestorPath returns the path of the closest ancestor property of the same type as typ,
+// if the type is recursive.
+func (o *objectMapper) ancestorPath(typ reflect.Type) (jsonpath.Path, bool) {
+	for i := len(o.ancestors) - 1; i >= 0; i-- {
+		if o.ancestors[i].typ == typ {
+			return o.ancestors[i].path, true
+		}
+	}
+	return jsonpath.Path{}, false
+}
+
+// isBeyondMaxDepth reports whether the nested properties of the property at path are deeper
+// than the depth set with [WithMaxDepth].
+func (o *objectMapper) isBeyondMaxDepth(path jsonpath.Path) bool {
+	return o.options.maxDepth != nil && pathDepth(path.String()) > *o.options.maxDepth
+}
+
+// hasNestedProperties reports whether a property of typ has nested properties which add a level of depth,
+// that is, whether typ is a struct with documented fields or an interface with registered implementations.
+func (o *objectMapper) hasNestedProperties(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Struct:
+		for _, field := range reflect.VisibleFields(typ) {
+			if !o.isPromotedField(typ, field) {
+				continue
+			}
+			if name, _ := o.structFieldName(field); name != "" {
+				return true
+			}
+		}
+		return false
+	case reflect.Interface:
+		return len(o.options.implementations[typ]) > 0
+	default:
+		return false
+	}
+}
+
+// structFieldName returns the name the field is documented under, or an empty string if it is not documented.
+// It also reports whether the field is hidden, that is, whether it is not encoded.
+func (o *objectMapper) structFieldName(field reflect.StructField) (name string, hidden bool) {
+	if !field.IsExported() {
+		if o.options.includeUnexported && !field.Anonymous {
+			return field.Name, true
+		}
+		return "", false
+	}
+	name, _, _ = strings.Cut(field.Tag.Get(o.options.tagName), ",")
+	switch {
+	case name == "-":
+		if o.options.includeHidden {
+			return field.Name, true
+		}
+		return "", false
+	case name != "":
+		return name, false
+	case isEmbeddedStruct(field):
+		if o.options.embeddedMode == EmbeddedModeNest {
+			return field.Name, false
+		}
+		return "", false
+	case o.options.untaggedFields == UntaggedFieldsUseFieldName:
+		return field.Name, false
+	default:
+		return "", false
+	}
+}
+
+// isPromotedField reports whether the fields of all the embedded structs the field is reached through
+// are promoted to structType.
+// Like with [encoding/json], embedded structs with a name in the tag are not promoted,
+// and neither are exported embedded structs in [EmbeddedModeNest], as they are documented as nested objects.
+func (o *objectMapper) isPromotedField(structType reflect.Type, field reflect.StructField) bool {
+	for i := 1; i < len(field.Index); i++ {
+		embedded := structType.FieldByIndex(field.Index[:i])
+		if name, _, _ := strings.Cut(embedded.Tag.Get(o.options.tagName), ","); name != "" {
+			return false
+		}
+		if embedded.IsExported() && o.options.embeddedMode == EmbeddedModeNest {
+			return false
+		}
+	}
+	return true
+}
+
+// setFieldInfo sets the struct tag and, with [WithLayoutInfo], the memory layout of struct field properties.
+func (o *objectMapper) setFieldInfo(doc PropertyDoc, property mappedProperty) PropertyDoc {
+	if property.field == nil {
+		return doc
+	}
+	doc.StructTag = property.field.Tag
+	if !o.options.layoutInfo {
+		return doc
+	}
+	if offset, ok := fieldOffset(property.structType, property.field.Index); ok {
+		doc.FieldSize = int(property.field.Type.Size())
+		doc.FieldOffset = int(offset)
+	}
+	return doc
+}
+
+// fieldOffset returns the offset of the field with the given index sequence within structType,
+// summing the offsets of the embedded structs the field is promoted from.
+// It returns false if the field is promoted through an embedded pointer.
+func fieldOffset(structType reflect.Type, index []int) (uintptr, bool) {
+	var offset uintptr
+	for i, fieldIndex := range index {
+		field := structType.Field(fieldIndex)
+		offset += field.Offset
+		if i == len(index)-1 {
+			break
+		}
+		if field.Type.Kind() != reflect.Struct {
+			return 0, false
+		}
+		structType = field.Type
+	}
+	return offset, true
+}
+
+// isEmbeddedStruct reports whether field is an embedded struct, whose fields are promoted to the parent struct.
+func isEmbeddedStruct(field reflect.StructField) bool {
+	if !field.Anonymous {
+		return false
+	}
+	typ := field.Type
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return typ.Kind() == reflect.Struct
+}
+
+func (o *objectMapper) setTypeInfo(doc PropertyDoc, typ reflect.Type) PropertyDoc {
+	doc.TypeInfo = o.typeInfo(typ)
 	return doc
 }
+
+func (o *objectMapper) typeInfo(typ reflect.Type) govy.TypeInfo {
+	return govy.TypeInfo(typeinfo.GetWithKinds(typ, o.scalarKind))
+}
+
+// scalarKind returns the kind of typ if it is registered with [WithScalarType]
+// or implements an interface registered with [WithScalarInterface].
+func (o *objectMapper) scalarKind(typ reflect.Type) (string, bool) {
+	if scalar, ok := o.options.scalarTypes[typ]; ok {
+		return scalar.kind, true
+	}
+	return scalarInterfaceKind(typ, o.options.scalarInterfaces)
+}
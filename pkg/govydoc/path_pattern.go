@@ -0,0 +1,92 @@
+package govydoc
+
+import (
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/nobl9/govy/pkg/jsonpath"
+)
+
+// recursiveWildcard matches any number of path segments in [WithFilteredPaths] patterns.
+const recursiveWildcard = "**"
+
+// matchesAnyPath reports whether the path is equal to, or matches as a pattern, any of the patterns.
+func matchesAnyPath(patterns []jsonpath.Path, propertyPath jsonpath.Path) bool {
+	return slices.ContainsFunc(patterns, func(pattern jsonpath.Path) bool {
+		return pattern.Equal(propertyPath) || matchPathPattern(pattern.String(), propertyPath.String())
+	})
+}
+
+// matchPathPattern reports whether the path matches the pattern segment by segment.
+// Name segments are matched with [path.Match], e.g. ".internal*" matches ".internalA",
+// and the "**" segment matches zero or more segments of any kind.
+// Slice element, map key, and map value segments only match the same segment,
+// which is how they were matched before patterns were supported.
+func matchPathPattern(pattern, propertyPath string) bool {
+	return matchSegments(pathSegments(pattern), pathSegments(propertyPath))
+}
+
+func matchSegments(patternSegments, segments []string) bool {
+	if len(patternSegments) == 0 {
+		return len(segments) == 0
+	}
+	if segmentName(patternSegments[0]) == recursiveWildcard {
+		for i := range len(segments) + 1 {
+			if matchSegments(patternSegments[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 || !matchSegment(patternSegments[0], segments[0]) {
+		return false
+	}
+	return matchSegments(patternSegments[1:], segments[1:])
+}
+
+func matchSegment(patternSegment, segment string) bool {
+	if isWildcardSegment(patternSegment) || isWildcardSegment(segment) {
+		return patternSegment == segment
+	}
+	name := segmentName(patternSegment)
+	matched, err := path.Match(name, segmentName(segment))
+	if err != nil {
+		// Names which are not valid patterns, e.g. with unbalanced brackets, are matched literally.
+		return name == segmentName(segment)
+	}
+	return matched
+}
+
+// pathSegments splits the path into its segments following the root, e.g. "$.items[*]" into ".items" and "[*]".
+func pathSegments(path string) []string {
+	var segments []string
+	for parent, ok := parentPath(path); ok; parent, ok = parentPath(path) {
+		segments = append(segments, path[len(parent):])
+		path = parent
+	}
+	slices.Reverse(segments)
+	return segments
+}
+
+// segmentName returns the unescaped name of a dotted or bracket-quoted name segment,
+// e.g. "name" for both ".name" and "['name']".
+// Other segments are returned unchanged.
+func segmentName(segment string) string {
+	switch {
+	case isWildcardSegment(segment):
+		return segment
+	case strings.HasPrefix(segment, "['"):
+		quoted := strings.TrimSuffix(strings.TrimPrefix(segment, "['"), "']")
+		var name strings.Builder
+		for i := 0; i < len(quoted); i++ {
+			if quoted[i] == '\\' && i+1 < len(quoted) {
+				i++
+			}
+			name.WriteByte(quoted[i])
+		}
+		return name.String()
+	default:
+		return strings.TrimPrefix(segment, ".")
+	}
+}
@@ -0,0 +1,68 @@
+package govydoc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_matchPathPattern(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		pattern  string
+		path     string
+		expected bool
+	}{
+		"exact path":                    {pattern: "$.name", path: "$.name", expected: true},
+		"different path":                {pattern: "$.name", path: "$.hobby"},
+		"name prefix":                   {pattern: "$.metadata.internal*", path: "$.metadata.internalA", expected: true},
+		"name prefix of other parent":   {pattern: "$.metadata.internal*", path: "$.spec.internalA"},
+		"name prefix of nested":         {pattern: "$.metadata.internal*", path: "$.metadata.internalA.id"},
+		"single character":              {pattern: "$.?ame", path: "$.name", expected: true},
+		"recursive wildcard":            {pattern: "$.**.secret", path: "$.a.b.secret", expected: true},
+		"recursive wildcard at root":    {pattern: "$.**.secret", path: "$.secret", expected: true},
+		"recursive wildcard in slice":   {pattern: "$.**.secret", path: "$.items[*].secret", expected: true},
+		"recursive wildcard in map":     {pattern: "$.**.secret", path: "$.data.*.secret", expected: true},
+		"recursive wildcard other name": {pattern: "$.**.secret", path: "$.a.secretive"},
+		"trailing recursive wildcard":   {pattern: "$.metadata.**", path: "$.metadata.labels.*~", expected: true},
+		"quoted name":                   {pattern: "$['app.*']", path: "$['app.version']", expected: true},
+		"quoted path name":              {pattern: "$.**.version", path: "$['app.kubernetes.io'].version", expected: true},
+		"map value":                     {pattern: "$.data.*", path: "$.data.*", expected: true},
+		"map value does not match key":  {pattern: "$.data.*", path: "$.data.*~"},
+		"map value does not match name": {pattern: "$.data.*", path: "$.data.name"},
+		"slice element":                 {pattern: "$.items[*]", path: "$.items[*]", expected: true},
+		"name does not match element":   {pattern: "$.items.*", path: "$.items[*]"},
+		"invalid pattern":               {pattern: "$['a[b']", path: "$['a[b']", expected: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, matchPathPattern(test.pattern, test.path))
+		})
+	}
+}
+
+func Test_segmentName(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		segment  string
+		expected string
+	}{
+		"dotted name":   {segment: ".name", expected: "name"},
+		"quoted name":   {segment: "['a.b']", expected: "a.b"},
+		"escaped quote": {segment: `['it\'s']`, expected: "it's"},
+		"slice element": {segment: "[*]", expected: "[*]"},
+		"map key":       {segment: ".*~", expected: ".*~"},
+		"map value":     {segment: ".*", expected: ".*"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, segmentName(test.segment))
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package govydoc
+
+import (
+	"reflect"
+	"time"
+)
+
+// ProgressStage is a milestone of documentation generation reported with [WithProgress].
+type ProgressStage string
+
+// Supported [ProgressStage] values, in the order of their occurrence.
+const (
+	// ProgressPackagesLoaded is reported once the module's packages are loaded.
+	// It is only reported by [Generate], even if the packages were loaded by one of its previous calls,
+	// and by [NewGenerator], with a nil [ProgressEvent.Type].
+	// [GenerateStream] reports it for every validator, like [Generate].
+	// [Generate] does not report it when the documentation is read from the cache, see [WithCacheDir].
+	ProgressPackagesLoaded ProgressStage = "packages-loaded"
+	// ProgressTypeParsed is reported once the Go documentation of the documented type is parsed.
+	ProgressTypeParsed ProgressStage = "type-parsed"
+	// ProgressPlanGenerated is reported once the govy validation plan is generated.
+	ProgressPlanGenerated ProgressStage = "plan-generated"
+	// ProgressMergeDone is reported once the documentation is merged and post-processed.
+	ProgressMergeDone ProgressStage = "merge-done"
+)
+
+// ProgressEvent describes a [ProgressStage] reached while documenting a type.
+type ProgressEvent struct {
+	Stage ProgressStage
+	// Type is the documented type.
+	Type reflect.Type
+	// Count is the number of items the stage produced, that is, the number of loaded packages,
+	// parsed documentation entries of Go types, validation plan properties, or documented properties.
+	Count int
+	// Duration is the time the stage took.
+	Duration time.Duration
+}
+
+// WithProgress returns an option that calls fn every time a [ProgressStage] is reached,
+// which is useful for displaying progress bars or profiling the generation.
+// The function is called synchronously.
+func WithProgress(fn func(event ProgressEvent)) GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.progress = fn
+		return options
+	}
+}
+
+// startProgress returns the start time of a [ProgressStage].
+// The clock is not read unless [WithProgress] is used.
+func (o generateOptions) startProgress() time.Time {
+	if o.progress == nil {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+func (o generateOptions) reportProgress(stage ProgressStage, typ reflect.Type, count int, start time.Time) {
+	if o.progress == nil {
+		return
+	}
+	o.progress(ProgressEvent{
+		Stage:    stage,
+		Type:     typ,
+		Count:    count,
+		Duration: time.Since(start),
+	})
+}
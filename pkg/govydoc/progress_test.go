@@ -0,0 +1,37 @@
+package govydoc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nobl9/govy/pkg/govy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nieomylnieja/govydoc/internal/testmodels"
+)
+
+func TestWithProgress(t *testing.T) {
+	var events []ProgressEvent
+	doc, err := Generate(govy.New[testmodels.Teacher](), WithProgress(func(event ProgressEvent) {
+		events = append(events, event)
+	}))
+	require.NoError(t, err)
+
+	require.Len(t, events, 4)
+	stages := make([]ProgressStage, 0, len(events))
+	for _, event := range events {
+		stages = append(stages, event.Stage)
+		assert.Equal(t, reflect.TypeFor[testmodels.Teacher](), event.Type)
+	}
+	assert.Equal(t, []ProgressStage{
+		ProgressPackagesLoaded,
+		ProgressTypeParsed,
+		ProgressPlanGenerated,
+		ProgressMergeDone,
+	}, stages)
+	assert.Positive(t, events[0].Count)
+	assert.Positive(t, events[0].Duration)
+	assert.Positive(t, events[1].Count)
+	assert.Equal(t, len(doc.Properties), events[3].Count)
+}
@@ -3,37 +3,199 @@ package govydoc
 import (
 	"regexp"
 	"slices"
+	"strconv"
 	"strings"
 
+	"github.com/nobl9/govy/pkg/govy"
 	"github.com/nobl9/govy/pkg/jsonpath"
+	"github.com/nobl9/govy/pkg/rules"
 )
 
 var (
 	enumDeclarationRegex = regexp.MustCompile(`(?s)ENUM(.*)`)
-	deprecatedRegex      = regexp.MustCompile(`(?m)^Deprecated:\s*(.*)$`)
+	enumValuesRegex      = regexp.MustCompile(`(?s)ENUM\((.*?)\)`)
+	// deprecatedRegex also matches the paragraph opening tag of [DocHTML] format.
+	deprecatedRegex = regexp.MustCompile(`(?m)^(?:<p>)?Deprecated:\s*(.*)$`)
+	// blankLinesRegex matches the blank lines left behind by an extracted paragraph.
+	blankLinesRegex = regexp.MustCompile(`\n{3,}`)
 )
 
-type propertyPostProcessor func(doc PropertyDoc) PropertyDoc
+// PropertyPostProcessor modifies the documentation of a single property, see [WithPropertyTransform].
+type PropertyPostProcessor func(doc PropertyDoc) PropertyDoc
 
-func postProcessProperties(doc ObjectDoc, filterPaths []jsonpath.Path, formatters ...propertyPostProcessor) ObjectDoc {
-	properties := make([]PropertyDoc, 0, len(doc.Properties))
-	for _, property := range doc.Properties {
-		if containsPath(filterPaths, property.Path) {
-			continue
+// newPropertyPostProcessing returns a function post-processing a single property with the formatters.
+// It returns false for the properties excluded with includePaths, see [WithIncludedPaths],
+// or filterPaths, see [WithFilteredPaths], and for the properties whose kind is not one of typeKinds,
+// unless typeKinds is empty, see [WithTypeKindFilter].
+// The children paths of the properties which refer to the properties excluded with includePaths are removed.
+func newPropertyPostProcessing(
+	includePaths, filterPaths []jsonpath.Path,
+	typeKinds []string,
+	formatters []PropertyPostProcessor,
+) func(PropertyDoc) (PropertyDoc, bool) {
+	isExcluded := func(string) bool { return false }
+	if len(includePaths) > 0 {
+		isExcluded = isExcludedFunc(includePaths)
+	}
+	return func(property PropertyDoc) (PropertyDoc, bool) {
+		if isExcluded(property.Path.String()) || matchesAnyPath(filterPaths, property.Path) {
+			return PropertyDoc{}, false
+		}
+		if len(typeKinds) > 0 && !slices.Contains(typeKinds, property.TypeInfo.Kind) {
+			return PropertyDoc{}, false
+		}
+		if len(includePaths) > 0 {
+			property.ChildrenPaths = slices.DeleteFunc(slices.Clone(property.ChildrenPaths), isExcluded)
 		}
 		for _, formatter := range formatters {
 			property = formatter(property)
 		}
-		properties = append(properties, property)
+		return property, true
+	}
+}
+
+// isExcludedFunc returns a function reporting whether a path is not selected with [WithIncludedPaths],
+// that is, whether it is neither one of includePaths nor their ancestor.
+func isExcludedFunc(includePaths []jsonpath.Path) func(path string) bool {
+	included := make(map[string]struct{}, len(includePaths))
+	for _, includePath := range includePaths {
+		for path, ok := includePath.String(), true; ok; path, ok = parentPath(path) {
+			included[path] = struct{}{}
+		}
+	}
+	return func(path string) bool {
+		_, found := included[trimWildcardSegments(path)]
+		return !found
+	}
+}
+
+// trimWildcardSegments removes the trailing slice element, map key, and map value segments from the path,
+// e.g. "$.data.*" becomes "$.data".
+func trimWildcardSegments(path string) string {
+	for {
+		parent, ok := parentPath(path)
+		if !ok || !isWildcardSegment(path[len(parent):]) {
+			return path
+		}
+		path = parent
+	}
+}
+
+// filterRules returns a post-processor removing the rules whose error code chain contains any of the errorCodes,
+// and the rules for which any of the filters returns true, see [WithRuleFilter].
+func filterRules(errorCodes []govy.ErrorCode, filters []func(RuleDoc) bool) PropertyPostProcessor {
+	return func(doc PropertyDoc) PropertyDoc {
+		if (len(errorCodes) == 0 && len(filters) == 0) || len(doc.Rules) == 0 {
+			return doc
+		}
+		doc.Rules = slices.DeleteFunc(slices.Clone(doc.Rules), func(rule govy.RulePlan) bool {
+			if slices.ContainsFunc(errorCodes, rule.ErrorCode.Has) {
+				return true
+			}
+			ruleDoc := newRuleDoc(rule)
+			return slices.ContainsFunc(filters, func(filter func(RuleDoc) bool) bool { return filter(ruleDoc) })
+		})
+		return doc
+	}
+}
+
+func stripPropertyDocumentation(property PropertyDoc) PropertyDoc {
+	property.TypeDoc = ""
+	property.FieldDoc = ""
+	property.RawTypeDoc = ""
+	property.RawFieldDoc = ""
+	property.TypeDocBlocks = nil
+	property.FieldDocBlocks = nil
+	property.DeprecatedDoc = ""
+	property.TypeDeprecatedDoc = ""
+	for j := range property.Methods {
+		property.Methods[j].Doc = ""
+	}
+	for j := range property.EnumConstants {
+		property.EnumConstants[j].Doc = ""
+	}
+	return property
+}
+
+func setDefaultValue(property PropertyDoc, tagKey string) PropertyDoc {
+	if value, ok := property.StructTag.Lookup(tagKey); ok {
+		property.DefaultValue = value
+		property.Default = parseDefaultValue(value, property.TypeInfo.Kind)
+	}
+	return property
+}
+
+// parseDefaultValue parses the default value of a property of the kind, see [PropertyDoc.Default].
+func parseDefaultValue(value, kind string) any {
+	var (
+		parsed any
+		err    error
+	)
+	switch kind {
+	case "int", "int8", "int16", "int32", "int64":
+		parsed, err = strconv.ParseInt(value, 10, 64)
+	case "uint", "uint8", "uint16", "uint32", "uint64", "uintptr":
+		parsed, err = strconv.ParseUint(value, 10, 64)
+	case "float32", "float64":
+		parsed, err = strconv.ParseFloat(value, 64)
+	case "bool":
+		parsed, err = strconv.ParseBool(value)
+	default:
+		return value
+	}
+	if err != nil {
+		return value
+	}
+	return parsed
+}
+
+func assignStableID(property PropertyDoc, fn func(PropertyDoc) string) PropertyDoc {
+	property.ID = fn(property)
+	if property.ID == "" {
+		property.ID = property.Path.String()
+	}
+	return property
+}
+
+// setRequired sets [PropertyDoc.Required] for properties with a required rule.
+// It must run after filterRules, so that filtered required rules are not taken into account.
+func setRequired(doc PropertyDoc) PropertyDoc {
+	doc.Required = slices.ContainsFunc(doc.Rules, isRequiredRule)
+	return doc
+}
+
+// restrictNullability disallows nil values of properties with a required rule,
+// and empty values of properties with a positive minimum length.
+// It must run after aggregateConstraints.
+func restrictNullability(doc PropertyDoc) PropertyDoc {
+	if slices.ContainsFunc(doc.Rules, isRequiredRule) {
+		doc.AllowsNull = false
+	}
+	if doc.Constraints != nil && doc.Constraints.MinLen != nil && *doc.Constraints.MinLen > 0 {
+		doc.AllowsEmpty = false
 	}
-	doc.Properties = properties
 	return doc
 }
 
-func containsPath(paths []jsonpath.Path, path jsonpath.Path) bool {
-	return slices.ContainsFunc(paths, func(candidate jsonpath.Path) bool {
-		return candidate.Equal(path)
-	})
+// isRequiredRule reports whether rule is an unconditional [rules.Required] rule.
+func isRequiredRule(rule govy.RulePlan) bool {
+	return rule.ErrorCode == rules.ErrorCodeRequired && len(rule.Conditions) == 0
+}
+
+// setAllowedValues sets [PropertyDoc.AllowedValues] to the values allowed by the validation rules,
+// falling back to the go-enum values declared in the type documentation.
+func setAllowedValues(doc PropertyDoc) PropertyDoc {
+	switch {
+	case len(doc.Values) > 0:
+		doc.AllowedValues = slices.Clone(doc.Values)
+	case len(doc.EnumValues) > 0:
+		doc.AllowedValues = slices.Clone(doc.EnumValues)
+	case len(doc.EnumConstants) > 0:
+		for _, constant := range doc.EnumConstants {
+			doc.AllowedValues = append(doc.AllowedValues, constant.Value)
+		}
+	}
+	return doc
 }
 
 func removeEnumDeclaration(doc PropertyDoc) PropertyDoc {
@@ -41,26 +203,48 @@ func removeEnumDeclaration(doc PropertyDoc) PropertyDoc {
 	return doc
 }
 
+// parseEnumValues returns the values of the go-enum declaration in the raw doc comment text,
+// e.g. ENUM(Red=1, Green, Blue), which is removed from the rendered documentation by removeEnumDeclaration.
+// Values can be separated by commas or new lines, and their assigned numbers are omitted.
+// The raw text is parsed, as rendering the comment joins the lines of multiline declarations.
+func parseEnumValues(text string) []string {
+	matches := enumValuesRegex.FindStringSubmatch(text)
+	if len(matches) < 2 {
+		return nil
+	}
+	var values []string
+	for value := range strings.FieldsFuncSeq(matches[1], func(r rune) bool { return r == ',' || r == '\n' }) {
+		name, _, _ := strings.Cut(value, "=")
+		if name = strings.TrimSpace(name); name != "" {
+			values = append(values, name)
+		}
+	}
+	return values
+}
+
 func removeTrailingWhitespace(doc PropertyDoc) PropertyDoc {
 	doc.TypeDoc = strings.TrimSpace(doc.TypeDoc)
 	doc.FieldDoc = strings.TrimSpace(doc.FieldDoc)
+	doc.RawTypeDoc = strings.TrimSpace(doc.RawTypeDoc)
+	doc.RawFieldDoc = strings.TrimSpace(doc.RawFieldDoc)
 	return doc
 }
 
+// extractDeprecatedInformation moves the Deprecated markers of [PropertyDoc.FieldDoc] and [PropertyDoc.TypeDoc]
+// to [PropertyDoc.DeprecatedDoc] and [PropertyDoc.TypeDeprecatedDoc] respectively.
 func extractDeprecatedInformation(doc PropertyDoc) PropertyDoc {
-	switch {
-	case deprecatedRegex.MatchString(doc.TypeDoc):
-		matches := deprecatedRegex.FindStringSubmatch(doc.TypeDoc)
-		if len(matches) > 1 {
-			doc.DeprecatedDoc = strings.TrimSpace(matches[1])
-		}
-		doc.TypeDoc = strings.TrimSpace(deprecatedRegex.ReplaceAllString(doc.TypeDoc, ""))
-	case deprecatedRegex.MatchString(doc.FieldDoc):
-		matches := deprecatedRegex.FindStringSubmatch(doc.FieldDoc)
-		if len(matches) > 1 {
-			doc.DeprecatedDoc = strings.TrimSpace(matches[1])
-		}
-		doc.FieldDoc = strings.TrimSpace(deprecatedRegex.ReplaceAllString(doc.FieldDoc, ""))
-	}
+	doc.FieldDoc, doc.DeprecatedDoc = extractDeprecatedMarker(doc.FieldDoc)
+	doc.TypeDoc, doc.TypeDeprecatedDoc = extractDeprecatedMarker(doc.TypeDoc)
 	return doc
 }
+
+// extractDeprecatedMarker returns the comment without its Deprecated marker and the text following the marker.
+// The paragraphs surrounding the marker are preserved.
+func extractDeprecatedMarker(comment string) (remainder, deprecated string) {
+	matches := deprecatedRegex.FindStringSubmatch(comment)
+	if matches == nil {
+		return comment, ""
+	}
+	remainder = blankLinesRegex.ReplaceAllString(deprecatedRegex.ReplaceAllString(comment, ""), "\n\n")
+	return strings.TrimSpace(remainder), strings.TrimSpace(matches[1])
+}
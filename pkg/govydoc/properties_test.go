@@ -0,0 +1,111 @@
+package govydoc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseEnumValues(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		text     string
+		expected []string
+	}{
+		"no declaration": {
+			text: "Color is a color.",
+		},
+		"single line": {
+			text:     "Color is a color.\n\nENUM(red, green, blue)\n",
+			expected: []string{"red", "green", "blue"},
+		},
+		"assigned numbers": {
+			text:     "ENUM(Red=1, Green, Blue = 5)",
+			expected: []string{"Red", "Green", "Blue"},
+		},
+		"multiline": {
+			text:     "Size is a size.\nENUM(\nsmall,\n  medium\nlarge,\n)\n",
+			expected: []string{"small", "medium", "large"},
+		},
+		"empty declaration": {
+			text: "ENUM()",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, parseEnumValues(test.text))
+		})
+	}
+}
+
+func TestParseDefaultValue(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		value    string
+		kind     string
+		expected any
+	}{
+		"string":         {value: "localhost", kind: "string", expected: "localhost"},
+		"int":            {value: "-30", kind: "int", expected: int64(-30)},
+		"uint":           {value: "30", kind: "uint16", expected: uint64(30)},
+		"float":          {value: "0.25", kind: "float32", expected: 0.25},
+		"bool":           {value: "true", kind: "bool", expected: true},
+		"invalid number": {value: "many", kind: "int", expected: "many"},
+		"other kind":     {value: "30s", kind: "duration", expected: "30s"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, parseDefaultValue(test.value, test.kind))
+		})
+	}
+}
+
+func TestExtractDeprecatedInformation(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		doc      PropertyDoc
+		expected PropertyDoc
+	}{
+		"no marker": {
+			doc:      PropertyDoc{FieldDoc: "Name of the user.", TypeDoc: "Name is a name."},
+			expected: PropertyDoc{FieldDoc: "Name of the user.", TypeDoc: "Name is a name."},
+		},
+		"field": {
+			doc:      PropertyDoc{FieldDoc: "Old name.\n\nDeprecated: Use Name instead."},
+			expected: PropertyDoc{FieldDoc: "Old name.", DeprecatedDoc: "Use Name instead."},
+		},
+		"type": {
+			doc:      PropertyDoc{TypeDoc: "Student is a student.\n\nDeprecated: Use Teacher instead.\n\nIt is old."},
+			expected: PropertyDoc{TypeDoc: "Student is a student.\n\nIt is old.", TypeDeprecatedDoc: "Use Teacher instead."},
+		},
+		"field and type": {
+			doc: PropertyDoc{
+				FieldDoc: "Deprecated: Use Name instead.",
+				TypeDoc:  "Student is a student.\nDeprecated: Use Teacher instead.",
+			},
+			expected: PropertyDoc{
+				TypeDoc:           "Student is a student.",
+				DeprecatedDoc:     "Use Name instead.",
+				TypeDeprecatedDoc: "Use Teacher instead.",
+			},
+		},
+		"html": {
+			doc:      PropertyDoc{FieldDoc: "<p>Old name.\n<p>Deprecated: Use Name instead."},
+			expected: PropertyDoc{FieldDoc: "<p>Old name.", DeprecatedDoc: "Use Name instead."},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, extractDeprecatedInformation(test.doc))
+		})
+	}
+}
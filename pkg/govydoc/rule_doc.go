@@ -0,0 +1,134 @@
+package govydoc
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nobl9/govy/pkg/govy"
+	"github.com/nobl9/govy/pkg/rules"
+)
+
+// RuleDoc describes a validation rule of a property independently of govy's plan types.
+type RuleDoc struct {
+	// Name identifies the kind of the rule, it is the rule's error code, e.g. "string_length".
+	// The govy validation plan does not record the names of the rules' constructors, like rules.EQ,
+	// so [govy.RulePlan.ErrorCode] is the best identifier of a rule available, e.g. "equal_to" for rules.EQ.
+	// Rules sharing an error code, like the ones created with custom constructors, cannot be told apart by it.
+	// It is empty for rules without an error code.
+	Name string `json:"name,omitempty"`
+	// Description is the human-readable description of the rule.
+	Description string `json:"description"`
+	// Details is the additional information about the rule.
+	Details string `json:"details,omitempty"`
+	// Conditions lists the descriptions of the predicates the rule is applied under.
+	Conditions []string `json:"conditions,omitempty"`
+	// Parameters are the rule's parameters recognized from its description, keyed by their names:
+	//   - "min" and "max" length of length rules, as integers
+	//   - "pattern" of regular expression rules
+	//   - "value" compared with by comparison rules, as a number if it is numeric, and as a string otherwise
+	//
+	// It is nil if the rule is not recognized.
+	Parameters map[string]any `json:"parameters,omitempty"`
+}
+
+var comparisonValueRegex = regexp.MustCompile(
+	`^must (?:not )?be (?:equal to|(?:greater|less) than(?: or equal to)?) '(.*)'$`)
+
+// setRuleDocs sets [PropertyDoc.RuleDocs] based on the property's rules.
+func setRuleDocs(doc PropertyDoc) PropertyDoc {
+	if len(doc.Rules) == 0 {
+		doc.RuleDocs = nil
+		return doc
+	}
+	doc.RuleDocs = make([]RuleDoc, 0, len(doc.Rules))
+	for _, rule := range doc.Rules {
+		doc.RuleDocs = append(doc.RuleDocs, newRuleDoc(rule))
+	}
+	return doc
+}
+
+// setConditions sets [PropertyDoc.Conditions] based on the property's rules.
+func setConditions(doc PropertyDoc) PropertyDoc {
+	doc.Conditions = nil
+	for _, rule := range doc.Rules {
+		for _, condition := range rule.Conditions {
+			if condition != "" && !slices.Contains(doc.Conditions, condition) {
+				doc.Conditions = append(doc.Conditions, condition)
+			}
+		}
+	}
+	return doc
+}
+
+func newRuleDoc(rule govy.RulePlan) RuleDoc {
+	return RuleDoc{
+		// The error code is the only identifier of the rule in the plan, see RuleDoc.Name.
+		Name:        string(rule.ErrorCode),
+		Description: rule.Description,
+		Details:     rule.Details,
+		Conditions:  slices.Clone(rule.Conditions),
+		Parameters:  ruleParameters(rule),
+	}
+}
+
+// ruleParameters parses the parameters of the rule from its description,
+// as rules' plans only expose their parameters through descriptions.
+func ruleParameters(rule govy.RulePlan) map[string]any {
+	switch rule.ErrorCode {
+	case rules.ErrorCodeStringLength, rules.ErrorCodeSliceLength, rules.ErrorCodeMapLength:
+		if m := lengthBetweenRegex.FindStringSubmatch(rule.Description); m != nil {
+			return lengthParameters(map[string]string{"min": m[1], "max": m[2]})
+		}
+	case rules.ErrorCodeStringMinLength, rules.ErrorCodeSliceMinLength, rules.ErrorCodeMapMinLength:
+		if m := minLengthRegex.FindStringSubmatch(rule.Description); m != nil {
+			return lengthParameters(map[string]string{"min": m[1]})
+		}
+	case rules.ErrorCodeStringMaxLength, rules.ErrorCodeSliceMaxLength, rules.ErrorCodeMapMaxLength:
+		if m := maxLengthRegex.FindStringSubmatch(rule.Description); m != nil {
+			return lengthParameters(map[string]string{"max": m[1]})
+		}
+	case rules.ErrorCodeStringMatchRegexp:
+		if pattern, found := strings.CutPrefix(rule.Description, matchRegexpDescriptionPrefix); found {
+			return map[string]any{"pattern": strings.TrimSuffix(strings.TrimPrefix(pattern, "'"), "'")}
+		}
+	case rules.ErrorCodeEqualTo, rules.ErrorCodeNotEqualTo,
+		rules.ErrorCodeGreaterThan, rules.ErrorCodeGreaterThanOrEqualTo,
+		rules.ErrorCodeLessThan, rules.ErrorCodeLessThanOrEqualTo:
+		if m := comparisonValueRegex.FindStringSubmatch(rule.Description); m != nil {
+			if value, err := strconv.ParseFloat(m[1], 64); err == nil {
+				return map[string]any{"value": value}
+			}
+			return map[string]any{"value": m[1]}
+		}
+	}
+	return nil
+}
+
+// lengthParameters converts the lengths to integers, it returns nil if any of them is not an integer.
+func lengthParameters(lengths map[string]string) map[string]any {
+	parameters := make(map[string]any, len(lengths))
+	for name, s := range lengths {
+		length, err := strconv.Atoi(s)
+		if err != nil {
+			return nil
+		}
+		parameters[name] = length
+	}
+	return parameters
+}
+
+func cloneRuleDocs(ruleDocs []RuleDoc) []RuleDoc {
+	if ruleDocs == nil {
+		return nil
+	}
+	clones := make([]RuleDoc, 0, len(ruleDocs))
+	for _, rule := range ruleDocs {
+		rule.Conditions = slices.Clone(rule.Conditions)
+		rule.Parameters = maps.Clone(rule.Parameters)
+		clones = append(clones, rule)
+	}
+	return clones
+}
@@ -0,0 +1,156 @@
+package govydoc
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/nobl9/govy/pkg/govy"
+	"github.com/nobl9/govy/pkg/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nieomylnieja/govydoc/internal/testmodels"
+)
+
+func TestGenerate_RuleDocs(t *testing.T) {
+	validator := govy.New(
+		govy.For(func(t testmodels.Teacher) string { return t.Hobby }).
+			WithName("hobby").
+			Rules(
+				rules.StringLength(3, 20),
+				rules.StringMatchRegexp(regexp.MustCompile(`^[a-z]+$`)).WithDetails("Lowercase letters only."),
+			),
+		govy.For(func(t testmodels.Teacher) int { return t.Age }).
+			WithName("age").
+			Rules(rules.GT(18)).
+			When(func(t testmodels.Teacher) bool { return t.Name != "" }, govy.WhenDescription("when named")),
+	).WithName("Teacher")
+
+	doc, err := Generate(validator)
+	require.NoError(t, err)
+
+	assert.Equal(t, []RuleDoc{
+		{
+			Name:        "string_length",
+			Description: "length must be between 3 and 20",
+			Parameters:  map[string]any{"min": 3, "max": 20},
+		},
+		{
+			Name:        "string_match_regexp",
+			Description: "string must match regular expression: '^[a-z]+$'",
+			Details:     "Lowercase letters only.",
+			Parameters:  map[string]any{"pattern": "^[a-z]+$"},
+		},
+	}, findProperty(t, doc, "$.hobby").RuleDocs)
+	assert.Equal(t, []RuleDoc{{
+		Name:        "greater_than",
+		Description: "must be greater than '18'",
+		Conditions:  []string{"when named"},
+		Parameters:  map[string]any{"value": 18.0},
+	}}, findProperty(t, doc, "$.age").RuleDocs)
+	assert.Nil(t, findProperty(t, doc, "$.students").RuleDocs)
+
+	t.Run("rule name", func(t *testing.T) {
+		validator := govy.New(
+			govy.For(func(t testmodels.Teacher) string { return t.Name }).
+				WithName("name").
+				Rules(rules.EQ("John")),
+		).WithName("Teacher")
+
+		doc, err := Generate(validator)
+		require.NoError(t, err)
+
+		name := findProperty(t, doc, "$.name")
+		require.Len(t, name.RuleDocs, 1)
+		assert.Equal(t, "equal_to", name.RuleDocs[0].Name)
+		assert.Equal(t, string(rules.ErrorCodeEqualTo), name.RuleDocs[0].Name)
+		assert.Equal(t, map[string]any{"value": "John"}, name.RuleDocs[0].Parameters)
+	})
+}
+
+func TestGenerate_Conditions(t *testing.T) {
+	validator := govy.New(
+		govy.For(func(t testmodels.Teacher) string { return t.Hobby }).
+			WithName("hobby").
+			Rules(rules.StringMaxLength(20)),
+		govy.For(func(t testmodels.Teacher) string { return t.Hobby }).
+			WithName("hobby").
+			Rules(rules.Forbidden[string]()).
+			When(func(t testmodels.Teacher) bool { return t.Age > 30 }, govy.WhenDescription("when above 30")),
+		govy.For(func(t testmodels.Teacher) string { return t.Hobby }).
+			WithName("hobby").
+			Rules(rules.StringNotEmpty()).
+			When(func(t testmodels.Teacher) bool { return t.Name != "" }, govy.WhenDescription("when named")).
+			When(func(t testmodels.Teacher) bool { return t.Age > 30 }, govy.WhenDescription("when above 30")),
+	).WithName("Teacher")
+
+	doc, err := Generate(validator)
+	require.NoError(t, err)
+
+	hobby := findProperty(t, doc, "$.hobby")
+	assert.ElementsMatch(t, []string{"when above 30", "when named"}, hobby.Conditions)
+	for _, rule := range hobby.RuleDocs {
+		if rule.Name == "string_max_length" {
+			assert.Empty(t, rule.Conditions)
+		}
+	}
+	assert.Nil(t, findProperty(t, doc, "$.age").Conditions)
+
+	doc, err = Generate(validator, WithRuleFilter(func(rule RuleDoc) bool { return rule.Name == "forbidden" }))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"when named", "when above 30"}, findProperty(t, doc, "$.hobby").Conditions)
+
+	doc, err = Generate(validator, WithFilteredRules(rules.ErrorCodeStringNotEmpty, rules.ErrorCodeForbidden))
+	require.NoError(t, err)
+	assert.Nil(t, findProperty(t, doc, "$.hobby").Conditions)
+}
+
+func Test_ruleParameters(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		rule     govy.RulePlan
+		expected map[string]any
+	}{
+		"minimum length": {
+			rule: govy.RulePlan{
+				Description: "length must be greater than or equal to 1",
+				ErrorCode:   rules.ErrorCodeSliceMinLength,
+			},
+			expected: map[string]any{"min": 1},
+		},
+		"maximum length": {
+			rule: govy.RulePlan{
+				Description: "length must be less than or equal to 5",
+				ErrorCode:   rules.ErrorCodeMapMaxLength,
+			},
+			expected: map[string]any{"max": 5},
+		},
+		"not equal to string": {
+			rule:     govy.RulePlan{Description: "must not be equal to 'admin'", ErrorCode: rules.ErrorCodeNotEqualTo},
+			expected: map[string]any{"value": "admin"},
+		},
+		"less than or equal to decimal": {
+			rule: govy.RulePlan{
+				Description: "must be less than or equal to '0.5'",
+				ErrorCode:   rules.ErrorCodeLessThanOrEqualTo,
+			},
+			expected: map[string]any{"value": 0.5},
+		},
+		"custom description": {
+			rule: govy.RulePlan{Description: "must be small", ErrorCode: rules.ErrorCodeLessThan},
+		},
+		"rule without parameters": {
+			rule: govy.RulePlan{Description: "string must not be empty", ErrorCode: rules.ErrorCodeStringNotEmpty},
+		},
+		"custom rule": {
+			rule: govy.RulePlan{Description: "length must be between 1 and 2"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, ruleParameters(test.rule))
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package govydoc
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/nobl9/govy/pkg/govy"
+)
+
+// scalarType describes a type documented as a leaf property with a custom kind, see [WithScalarType].
+type scalarType struct {
+	kind        string
+	description string
+}
+
+// defaultScalarTypes are registered for every [Generate] call and can be overridden with [WithScalarType].
+var defaultScalarTypes = map[reflect.Type]scalarType{
+	reflect.TypeFor[json.Number](): {
+		kind:        "number",
+		description: "An arbitrary-precision JSON number, for example 3.14 or 1e100.",
+	},
+	reflect.TypeFor[time.Duration](): {
+		kind: "duration",
+		description: "A duration, encoded as an integer number of nanoseconds." +
+			" Types with custom decoding often accept strings like \"30s\", \"1.5h\" or \"2h45m\" instead.",
+	},
+	reflect.TypeFor[time.Time](): {
+		kind:        "datetime",
+		description: "A point in time, formatted as an RFC 3339 timestamp, for example \"2006-01-02T15:04:05Z\".",
+	},
+}
+
+// WithScalarType returns an option that documents T as a scalar leaf property
+// with the given [govy.TypeInfo] kind and type documentation, instead of its Go kind and doc comment.
+// The properties of T, like its struct fields, are not documented.
+// It is useful for types with custom JSON encoding, e.g. a struct encoded as a string.
+// [json.Number] is registered by default with the "number" kind, [time.Duration] with the "duration" kind,
+// and [time.Time] with the "datetime" kind.
+func WithScalarType[T any](kind, description string) GenerateOption {
+	return func(options generateOptions) generateOptions {
+		merged := maps.Clone(options.scalarTypes)
+		if merged == nil {
+			merged = make(map[reflect.Type]scalarType, 1)
+		}
+		merged[reflect.TypeFor[T]()] = scalarType{kind: kind, description: description}
+		options.scalarTypes = merged
+		return options
+	}
+}
+
+// withDefaultScalarTypes returns scalarTypes extended with [defaultScalarTypes] which were not overridden.
+func withDefaultScalarTypes(scalarTypes map[reflect.Type]scalarType) map[reflect.Type]scalarType {
+	merged := maps.Clone(defaultScalarTypes)
+	maps.Copy(merged, scalarTypes)
+	return merged
+}
+
+// scalarInterface describes an interface whose implementations are documented as leaf properties
+// with a custom kind, see [WithScalarInterface].
+type scalarInterface struct {
+	iface reflect.Type
+	kind  string
+}
+
+var (
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+)
+
+// defaultScalarInterfaces are registered for every [Generate] call and can be overridden with [WithScalarInterface].
+var defaultScalarInterfaces = []scalarInterface{
+	{iface: textMarshalerType, kind: "string"},
+}
+
+// WithScalarInterface returns an option that documents the types implementing the interface iface
+// as scalar leaf properties with the given [govy.TypeInfo] kind.
+// The properties of such types, like their struct fields, are not documented,
+// while their doc comments are, unlike with [WithScalarType], which takes precedence over it.
+// The interface is passed as a nil pointer, e.g. (*json.Marshaler)(nil).
+// Interfaces are matched in the order of registration.
+//
+// [encoding.TextMarshaler] is registered by default with the "string" kind, as its implementations,
+// like [net.IP], are encoded as JSON strings. Like with [encoding/json], types implementing [json.Marshaler]
+// are not matched by it, as MarshalJSON takes precedence over MarshalText.
+// An empty kind unregisters the interface.
+//
+// Only the methods of the type itself are matched, not the ones with pointer receivers,
+// which [encoding/json] only calls for addressable values, e.g. values referenced by pointers or slice elements,
+// but not for values of struct fields passed to [json.Marshal] by value.
+// Types implementing iface with pointer receivers are documented like any other type.
+//
+// [Generate] returns an error if iface is not a pointer to an interface.
+func WithScalarInterface(iface any, kind string) GenerateOption {
+	return func(options generateOptions) generateOptions {
+		options.scalarInterfaces = append(
+			slices.Clone(options.scalarInterfaces),
+			scalarInterface{iface: reflect.TypeOf(iface), kind: kind},
+		)
+		return options
+	}
+}
+
+// withDefaultScalarInterfaces validates the registrations of [WithScalarInterface]
+// and returns them preceded by the [defaultScalarInterfaces] which were not overridden.
+// Registrations with an empty kind are removed.
+func withDefaultScalarInterfaces(registrations []scalarInterface) ([]scalarInterface, error) {
+	scalarInterfaces := slices.Clone(defaultScalarInterfaces)
+	for _, registration := range registrations {
+		iface := registration.iface
+		if iface == nil || iface.Kind() != reflect.Pointer || iface.Elem().Kind() != reflect.Interface {
+			return nil, fmt.Errorf("invalid scalar interface %v: interface must be passed as a nil pointer, "+
+				"e.g. (*json.Marshaler)(nil)", iface)
+		}
+		registration.iface = iface.Elem()
+		scalarInterfaces = slices.DeleteFunc(scalarInterfaces, func(s scalarInterface) bool {
+			return s.iface == registration.iface
+		})
+		if registration.kind != "" {
+			scalarInterfaces = append(scalarInterfaces, registration)
+		}
+	}
+	return scalarInterfaces, nil
+}
+
+// scalarInterfaceKind returns the kind of typ if it implements any of scalarInterfaces with value receivers.
+// Types implementing [json.Marshaler] do not match [encoding.TextMarshaler], see [WithScalarInterface].
+func scalarInterfaceKind(typ reflect.Type, scalarInterfaces []scalarInterface) (string, bool) {
+	if typ.Kind() == reflect.Interface {
+		return "", false
+	}
+	for _, scalar := range scalarInterfaces {
+		if scalar.iface == textMarshalerType && typ.Implements(jsonMarshalerType) {
+			continue
+		}
+		if typ.Implements(scalar.iface) {
+			return scalar.kind, true
+		}
+	}
+	return "", false
+}
+
+// scalarTypeDescriptions maps the keys of registered scalar types, as returned by [PropertyDoc.key],
+// to their descriptions.
+func scalarTypeDescriptions(scalarTypes map[reflect.Type]scalarType) map[string]string {
+	descriptions := make(map[string]string, len(scalarTypes))
+	for typ, scalar := range scalarTypes {
+		doc := PropertyDoc{PropertyPlan: govy.PropertyPlan{TypeInfo: TypeInfoOf(typ)}}
+		descriptions[doc.key()] = scalar.description
+	}
+	return descriptions
+}
@@ -0,0 +1,210 @@
+package govydoc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"maps"
+	"reflect"
+
+	"github.com/nobl9/govy/pkg/govy"
+	"github.com/nobl9/govy/pkg/jsonpath"
+
+	"github.com/nieomylnieja/govydoc/internal/godoc"
+)
+
+// GenerateSeq is like [Generate], but instead of collecting the documentation of all the properties
+// in [ObjectDoc.Properties], it returns an iterator which documents the properties one at a time,
+// as the type handled by validator is mapped, so that they can be processed, e.g. written out, incrementally.
+// The iterator yields the same properties, in the same order, as [ObjectDoc.Properties] returned by [Generate]
+// with the same options.
+// If mapping the type fails, the iterator yields the error and stops.
+//
+// The Go documentation and the validation plan are loaded before GenerateSeq returns,
+// and its errors are returned like the errors of [Generate].
+// Documentation of the object as a whole, like [ObjectDoc.Examples], [ObjectDoc.UnionGroups],
+// or [ObjectDoc.PlanWarnings], is not generated.
+// GenerateSeq returns an error for [WithSortedPaths] and [WithDocLinkAnchors],
+// which require all the properties to be known up front.
+func GenerateSeq[T any](
+	validator govy.Validator[T],
+	opts ...GenerateOption,
+) (iter.Seq2[PropertyDoc, error], error) {
+	options, err := newGenerateOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	if options.sortedPaths {
+		return nil, errors.New("cannot generate properties sequence with sorted paths: " +
+			"sorting requires all the properties to be generated first")
+	}
+	if options.docLinkAnchors {
+		return nil, errors.New("cannot generate properties sequence with doc link anchors: " +
+			"linking requires all the documented types to be known first")
+	}
+	typ := reflect.TypeFor[T]()
+	if err = validateDocumentedType(typ); err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	loadParser := sharedParserLoader(ctx, typ, options)
+	goDocs, _, err := parseGoDoc(ctx, typ, loadParser, &sharedCaches, options)
+	if err != nil {
+		return nil, err
+	}
+	// The documented interfaces are not known until the type is mapped,
+	// so the documentation of all the registered implementations is loaded.
+	for _, impls := range options.implementations {
+		for _, impl := range impls {
+			implDoc, _, err := parseGoDoc(ctx, impl, loadParser, &sharedCaches, options)
+			if err != nil {
+				return nil, err
+			}
+			maps.Copy(goDocs, implDoc)
+		}
+	}
+
+	start := options.startProgress()
+	plan, err := govy.Plan(validator, options.govyPlanOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate validation plan for %s: %w", typ, err)
+	}
+	options.reportProgress(ProgressPlanGenerated, typ, len(plan.Properties), start)
+
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return func(yield func(PropertyDoc, error) bool) {
+		seq := newPropertySeq(plan, goDocs, options)
+		stopped := false
+		err := newObjectMapper(options).Walk(typ, jsonpath.Parse("$"), func(property PropertyDoc) bool {
+			property, ok := seq.document(property)
+			if !ok {
+				return true
+			}
+			stopped = !yield(property, nil)
+			return !stopped
+		})
+		if err != nil && !stopped {
+			yield(PropertyDoc{}, fmt.Errorf("failed to map properties of %s: %w", typ, err))
+		}
+	}, nil
+}
+
+// propertySeq documents the mapped properties one at a time.
+// It is the single pipeline documenting the properties of both [Generate] and [GenerateSeq].
+// Only the documentation of the object as a whole is generated separately, see [generate].
+type propertySeq struct {
+	options            generateOptions
+	goDocs             godoc.Docs
+	scalarDescriptions map[string]string
+	// plans and expandedPlans hold the validation plan properties by their renamed and expanded paths,
+	// see [applyPropertyPlan] and [planWarnings].
+	plans         map[string]plannedProperty
+	expandedPlans map[string]plannedProperty
+	// fieldDocs holds the documentation of struct fields by their paths,
+	// it is set when their parent is documented and removed once the field is documented.
+	fieldDocs map[string]godoc.Doc
+	// anonymousStructs holds the documentation of struct fields declared with anonymous struct types
+	// by their paths, see [anonymousStructDoc].
+	anonymousStructs  map[string]godoc.Doc
+	postProcess       func(PropertyDoc) (PropertyDoc, bool)
+	exampleReferences *exampleReferences
+}
+
+type plannedProperty struct {
+	plan          govy.PropertyPlan
+	validatorName string
+}
+
+// newPropertySeq creates a [propertySeq], plan is nil if the properties are documented without a validator.
+func newPropertySeq(plan *govy.ValidatorPlan, goDocs godoc.Docs, options generateOptions) *propertySeq {
+	postProcess := newPropertyPostProcessing(
+		options.includePaths,
+		options.filterPaths,
+		options.typeKinds,
+		postProcessors(options),
+	)
+	seq := &propertySeq{
+		options:            options,
+		goDocs:             goDocs,
+		scalarDescriptions: scalarTypeDescriptions(options.scalarTypes),
+		plans:              make(map[string]plannedProperty),
+		expandedPlans:      make(map[string]plannedProperty),
+		fieldDocs:          make(map[string]godoc.Doc),
+		anonymousStructs:   make(map[string]godoc.Doc),
+		postProcess:        postProcess,
+		exampleReferences:  newExampleReferences(options),
+	}
+	if plan == nil {
+		return seq
+	}
+	for _, propPlan := range plan.Properties {
+		planned := plannedProperty{plan: *propPlan, validatorName: pathSegmentName(propPlan.Path)}
+		planned.plan.Path = renamePlanPath(propPlan.Path, options.nameMapping)
+		seq.plans[planned.plan.Path.String()] = planned
+		// Names set with WithName can use nested notation, e.g. "address.city".
+		if expanded := expandNestedNames(planned.plan.Path); !expanded.Equal(planned.plan.Path) {
+			planned.plan.Path = expanded
+			seq.expandedPlans[expanded.String()] = planned
+		}
+	}
+	return seq
+}
+
+// document documents the mapped property.
+// It returns false if the property is excluded from the documentation.
+func (s *propertySeq) document(property PropertyDoc) (PropertyDoc, bool) {
+	path := property.Path.String()
+	planned, found := s.plans[path]
+	if !found {
+		planned, found = s.expandedPlans[path]
+	}
+	if found {
+		property = applyPropertyPlan(property, planned.plan, planned.validatorName)
+	}
+	goDoc, found := propertyGoDoc(property, s.goDocs)
+	if found {
+		property = mergeTypeDoc(property, goDoc, s.scalarDescriptions, s.options)
+	} else if goDoc, found = anonymousStructDoc(property, s.anonymousStructs); found {
+		property = mergeAnonymousStructDoc(property, goDoc, s.options)
+	}
+	for name, field := range goDoc.StructFields {
+		fieldPath := property.Path.Name(name).String()
+		s.fieldDocs[fieldPath] = field
+		if field.Name == "" && len(field.StructFields) > 0 {
+			s.anonymousStructs[fieldPath] = field
+		}
+	}
+	if field, found := s.fieldDocs[path]; found {
+		property = mergeFieldDoc(property, field, s.options)
+		delete(s.fieldDocs, path)
+	}
+	property = applyTypeDocOverride(property, s.options.typeDocOverrides, s.options.rawDocs)
+
+	property, ok := s.postProcess(property)
+	if !ok {
+		return PropertyDoc{}, false
+	}
+	if s.options.arrayToken != "" {
+		property = replacePropertyArrayToken(property, s.options.arrayToken)
+	}
+	if s.options.exampleDir != "" {
+		property = s.exampleReferences.cut(property)
+	}
+	if s.options.minimalOutput {
+		property = stripPropertyDocumentation(property)
+	}
+	if s.options.defaultTag != "" {
+		property = setDefaultValue(property, s.options.defaultTag)
+	}
+	if s.options.stableIDs != nil {
+		property = assignStableID(property, s.options.stableIDs)
+	}
+	for _, transform := range s.options.propertyTransforms {
+		property = transform(property)
+	}
+	return property, true
+}
@@ -0,0 +1,139 @@
+package govydoc
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/nobl9/govy/pkg/govy"
+	"github.com/nobl9/govy/pkg/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nieomylnieja/govydoc/internal/testmodels"
+)
+
+func TestGenerateSeq(t *testing.T) {
+	validator := govy.New(
+		govy.For(func(t testmodels.Teacher) string { return t.Name }).
+			WithName("name").
+			Required().
+			Rules(rules.EQ("John")),
+		govy.ForSlice(func(t testmodels.Teacher) []testmodels.Student { return t.Students }).
+			WithName("students").
+			IncludeForEach(govy.New(
+				govy.For(func(s testmodels.Student) string { return s.Name }).
+					WithName("name").
+					Rules(rules.StringMinLength(1)),
+			)),
+	).WithName("Teacher")
+
+	tests := map[string][]GenerateOption{
+		"default":         nil,
+		"included paths":  {WithIncludedPaths("$.students[*].name"), WithFilteredPaths("$.students[*].age")},
+		"filtered paths":  {WithFilteredPaths("$.students")},
+		"raw docs":        {WithRawDocs(), WithDocBlocks(), WithSourcePositions()},
+		"minimal output":  {WithMinimalOutput()},
+		"array token":     {WithArrayToken("[]")},
+		"max depth":       {WithMaxDepth(0)},
+		"stable IDs":      {WithStableIDs(func(PropertyDoc) string { return "" })},
+		"filtered rules":  {WithFilteredRules(rules.ErrorCodeStringMinLength)},
+		"type overrides":  {WithTypeDocOverride("fmt", "Stringer", "Any value with a String method.")},
+		"declared order":  {WithDeclarationOrder(), WithDocFormat(DocPlain)},
+		"name mapping":    {WithNameMapping(map[string]string{"$.students": "$.pupils"})},
+		"layout and tags": {WithLayoutInfo(), WithDefaultTag("default")},
+		"type kinds":      {WithTypeKindFilter("struct", "string")},
+		"scalar iface":    {WithScalarInterface((*fmt.Stringer)(nil), "string")},
+		"transform":       {WithPropertyTransform(func(p PropertyDoc) PropertyDoc { p.TypeDoc = ""; return p })},
+	}
+	for name, opts := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			doc, err := Generate(validator, opts...)
+			require.NoError(t, err)
+
+			seq, err := GenerateSeq(validator, opts...)
+			require.NoError(t, err)
+			var properties []PropertyDoc
+			for property, err := range seq {
+				require.NoError(t, err)
+				properties = append(properties, property)
+			}
+			assert.Equal(t, doc.Properties, properties)
+		})
+	}
+
+	t.Run("interface implementations", func(t *testing.T) {
+		t.Parallel()
+		alertValidator := govy.New[testmodels.Alert]().WithName("Alert")
+		opts := []GenerateOption{WithInterfaceImplementations(
+			(*testmodels.Channel)(nil),
+			testmodels.EmailChannel{},
+			&testmodels.WebhookChannel{},
+		)}
+		doc, err := Generate(alertValidator, opts...)
+		require.NoError(t, err)
+
+		seq, err := GenerateSeq(alertValidator, opts...)
+		require.NoError(t, err)
+		var properties []PropertyDoc
+		for property, err := range seq {
+			require.NoError(t, err)
+			properties = append(properties, property)
+		}
+		assert.Equal(t, doc.Properties, properties)
+	})
+
+	t.Run("anonymous structs", func(t *testing.T) {
+		t.Parallel()
+		pipelineValidator := govy.New[testmodels.Pipeline]().WithName("Pipeline")
+		opts := []GenerateOption{WithDeclarationOrder(), WithArrayToken("[]")}
+		doc, err := Generate(pipelineValidator, opts...)
+		require.NoError(t, err)
+
+		seq, err := GenerateSeq(pipelineValidator, opts...)
+		require.NoError(t, err)
+		var properties []PropertyDoc
+		for property, err := range seq {
+			require.NoError(t, err)
+			properties = append(properties, property)
+		}
+		assert.Equal(t, doc.Properties, properties)
+	})
+
+	t.Run("break", func(t *testing.T) {
+		t.Parallel()
+		seq, err := GenerateSeq(validator)
+		require.NoError(t, err)
+
+		var paths []string
+		for property, err := range seq {
+			require.NoError(t, err)
+			paths = append(paths, property.Path.String())
+			if len(paths) == 3 {
+				break
+			}
+		}
+		assert.Equal(t, []string{"$", "$.name", "$.hobby"}, paths)
+	})
+
+	t.Run("sorted paths", func(t *testing.T) {
+		t.Parallel()
+		_, err := GenerateSeq(validator, WithSortedPaths())
+		require.EqualError(t, err, "cannot generate properties sequence with sorted paths: "+
+			"sorting requires all the properties to be generated first")
+	})
+
+	t.Run("yield panic", func(t *testing.T) {
+		t.Parallel()
+		seq, err := GenerateSeq(validator)
+		require.NoError(t, err)
+
+		errPanic := errors.New("panic")
+		assert.PanicsWithError(t, errPanic.Error(), func() {
+			for range seq {
+				panic(errPanic)
+			}
+		})
+	})
+}
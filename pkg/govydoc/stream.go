@@ -0,0 +1,103 @@
+package govydoc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+
+	"github.com/nobl9/govy/pkg/govy"
+
+	"github.com/nieomylnieja/govydoc/internal/godoc"
+)
+
+// Format is the encoding of the documentation written by [GenerateStream].
+type Format string
+
+// Supported [Format] values.
+const (
+	// FormatNDJSON writes every [ObjectDoc] as a single line of JSON.
+	FormatNDJSON Format = "ndjson"
+	// FormatJSONArray writes a JSON array of [ObjectDoc], one element at a time.
+	FormatJSONArray Format = "json-array"
+)
+
+// AnyValidator is a validator of any type which can be documented with [GenerateStream] or [Generator].
+// Use [NewAnyValidator] to create it.
+type AnyValidator interface {
+	generate(ctx context.Context, generator *Generator, opts []GenerateOption) (ObjectDoc, error)
+}
+
+// NewAnyValidator wraps validator, along with the options passed to [Generate] for it, into [AnyValidator].
+func NewAnyValidator[T any](validator govy.Validator[T], opts ...GenerateOption) AnyValidator {
+	return anyValidator[T]{validator: validator, opts: opts}
+}
+
+type anyValidator[T any] struct {
+	validator govy.Validator[T]
+	opts      []GenerateOption
+}
+
+// generate documents the validator with opts followed by the validator's own options.
+// If generator is nil, the parser and caches shared by [Generate] calls are used, see [sharedParserLoader].
+func (a anyValidator[T]) generate(ctx context.Context, generator *Generator, opts []GenerateOption) (ObjectDoc, error) {
+	options, err := newGenerateOptions(append(slices.Clone(opts), a.opts...))
+	if err != nil {
+		return ObjectDoc{}, err
+	}
+	if generator == nil {
+		return generate(ctx, &a.validator, sharedParserLoader(ctx, reflect.TypeFor[T](), options), &sharedCaches, options)
+	}
+	loadParser := func() (*godoc.Parser, error) { return generator.goDocParser, nil }
+	return generate(ctx, &a.validator, loadParser, &generator.caches, options)
+}
+
+// GenerateStream generates documentation for every validator and writes it to w in the given format
+// as soon as it is generated, instead of holding all the documents in memory.
+// The module's packages are loaded the same way as with [Generate], that is, they are loaded once
+// and shared with the other calls, taking [WithLazyLoading] and [WithModuleRoots] of every validator into account.
+func GenerateStream(w io.Writer, format Format, validators ...AnyValidator) error {
+	return GenerateStreamContext(context.Background(), w, format, validators...)
+}
+
+// GenerateStreamContext is like [GenerateStream], but stops loading the module's packages when ctx is done,
+// in which case ctx's error is returned.
+// The documentation written before ctx is done is not removed from w.
+func GenerateStreamContext(ctx context.Context, w io.Writer, format Format, validators ...AnyValidator) error {
+	if format != FormatNDJSON && format != FormatJSONArray {
+		return fmt.Errorf("unsupported stream format %q", format)
+	}
+
+	if format == FormatJSONArray {
+		if _, err := io.WriteString(w, "["); err != nil {
+			return fmt.Errorf("failed to write documentation: %w", err)
+		}
+	}
+	for i, validator := range validators {
+		doc, err := validator.generate(ctx, nil, nil)
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(doc)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s documentation: %w", doc.Name, err)
+		}
+		switch {
+		case format == FormatNDJSON:
+			data = append(data, '\n')
+		case i > 0:
+			data = append([]byte(","), data...)
+		}
+		if _, err = w.Write(data); err != nil {
+			return fmt.Errorf("failed to write %s documentation: %w", doc.Name, err)
+		}
+	}
+	if format == FormatJSONArray {
+		if _, err := io.WriteString(w, "]\n"); err != nil {
+			return fmt.Errorf("failed to write documentation: %w", err)
+		}
+	}
+	return nil
+}
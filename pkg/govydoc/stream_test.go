@@ -0,0 +1,81 @@
+package govydoc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/nobl9/govy/pkg/govy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nieomylnieja/govydoc/internal/testmodels"
+)
+
+func TestGenerateStream(t *testing.T) {
+	validators := []AnyValidator{
+		NewAnyValidator(govy.New[testmodels.Teacher]().WithName("Teacher")),
+		NewAnyValidator(govy.New[testmodels.Person]().WithName("Person"), WithFilteredPaths("$.address")),
+		NewAnyValidator(govy.New[testmodels.Address]().WithName("Address")),
+	}
+
+	t.Run("ndjson", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := GenerateStream(&buf, FormatNDJSON, validators...)
+		require.NoError(t, err)
+
+		var names []string
+		scanner := bufio.NewScanner(&buf)
+		scanner.Buffer(nil, 1024*1024)
+		for scanner.Scan() {
+			var doc ObjectDoc
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &doc))
+			names = append(names, doc.Name)
+			if doc.Name == "Person" {
+				assert.NotContains(t, propertyPaths(doc), "$.address")
+			}
+		}
+		require.NoError(t, scanner.Err())
+		assert.Equal(t, []string{"Teacher", "Person", "Address"}, names)
+	})
+
+	t.Run("json array", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := GenerateStream(&buf, FormatJSONArray, validators...)
+		require.NoError(t, err)
+
+		var docs []ObjectDoc
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &docs))
+		assert.Len(t, docs, 3)
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		err := GenerateStreamContext(ctx, &bytes.Buffer{}, FormatNDJSON, validators...)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("module roots", func(t *testing.T) {
+		err := GenerateStream(&bytes.Buffer{}, FormatNDJSON,
+			NewAnyValidator(govy.New[testmodels.Address](), WithModuleRoots(t.TempDir())))
+		require.ErrorContains(t, err, "go.mod")
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		err := GenerateStream(&bytes.Buffer{}, "yaml", validators...)
+		require.EqualError(t, err, `unsupported stream format "yaml"`)
+	})
+
+	t.Run("invalid option", func(t *testing.T) {
+		err := GenerateStream(
+			&bytes.Buffer{},
+			FormatNDJSON,
+			NewAnyValidator(govy.New[testmodels.Address](), WithArrayToken("*")),
+		)
+		require.EqualError(t, err, `invalid array token "*": token must be enclosed in square brackets`)
+	})
+}
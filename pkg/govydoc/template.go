@@ -0,0 +1,124 @@
+package govydoc
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+)
+
+// RenderTemplate executes tmpl with o as its data and writes the result to w.
+// The template has access to every exported field of [ObjectDoc] and [PropertyDoc].
+// Register the helpers returned by [FuncMap] with [template.Template.Funcs] before parsing
+// the template in order to use them.
+func RenderTemplate(o ObjectDoc, tmpl *template.Template, w io.Writer) error {
+	if tmpl == nil {
+		return errors.New("template cannot be nil")
+	}
+	if err := tmpl.Execute(w, o); err != nil {
+		return fmt.Errorf("failed to render %s template: %w", tmpl.Name(), err)
+	}
+	return nil
+}
+
+// FuncMap returns the template helpers available to templates rendered with [RenderTemplate].
+//
+//   - childrenOf takes an [ObjectDoc] and a [PropertyDoc] and returns the property's
+//     immediate children in the order of [PropertyDoc.ChildrenPaths].
+//   - isDeprecated reports whether a [PropertyDoc] has a [PropertyDoc.DeprecatedDoc].
+//     Properties of a deprecated type, see [PropertyDoc.TypeDeprecatedDoc], are not deprecated themselves.
+//   - ruleList returns the descriptions of a [PropertyDoc] rules,
+//     with the rule's conditions appended in parentheses.
+//   - isScalarMap reports whether a [PropertyDoc] is a map with scalar values, e.g. map[string]int.
+//   - collapsedProperties takes an [ObjectDoc] and returns its properties without the key and value
+//     properties of scalar-valued maps, which allows presenting such maps as a single entry.
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"childrenOf":          childrenOf,
+		"isDeprecated":        isDeprecated,
+		"ruleList":            ruleList,
+		"isScalarMap":         isScalarMap,
+		"collapsedProperties": collapsedProperties,
+	}
+}
+
+func childrenOf(o ObjectDoc, property PropertyDoc) []PropertyDoc {
+	children := make([]PropertyDoc, 0, len(property.ChildrenPaths))
+	for _, childPath := range property.ChildrenPaths {
+		for _, candidate := range o.Properties {
+			if candidate.Path.String() == childPath {
+				children = append(children, candidate)
+				break
+			}
+		}
+	}
+	return children
+}
+
+func isDeprecated(property PropertyDoc) bool {
+	return property.DeprecatedDoc != ""
+}
+
+func ruleList(property PropertyDoc) []string {
+	list := make([]string, 0, len(property.Rules))
+	for _, rule := range property.Rules {
+		description := rule.Description
+		if len(rule.Conditions) > 0 {
+			description += " (" + strings.Join(rule.Conditions, ", ") + ")"
+		}
+		list = append(list, description)
+	}
+	return list
+}
+
+func isScalarMap(property PropertyDoc) bool {
+	kind, found := strings.CutPrefix(property.TypeInfo.Kind, "map[")
+	if !found {
+		return false
+	}
+	depth := 1
+	for i, r := range kind {
+		switch r {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		}
+		if depth == 0 {
+			return isScalarKind(kind[i+1:])
+		}
+	}
+	return false
+}
+
+func isScalarKind(kind string) bool {
+	switch {
+	case strings.HasPrefix(kind, "map["), strings.HasPrefix(kind, "["):
+		return false
+	case kind == "struct", kind == "interface", kind == "":
+		return false
+	default:
+		return true
+	}
+}
+
+func collapsedProperties(o ObjectDoc) []PropertyDoc {
+	collapsed := make(map[string]struct{})
+	for _, property := range o.Properties {
+		if !isScalarMap(property) {
+			continue
+		}
+		for _, childPath := range property.ChildrenPaths {
+			collapsed[childPath] = struct{}{}
+		}
+	}
+	properties := make([]PropertyDoc, 0, len(o.Properties))
+	for _, property := range o.Properties {
+		if _, found := collapsed[property.Path.String()]; found {
+			continue
+		}
+		properties = append(properties, property)
+	}
+	return properties
+}
@@ -0,0 +1,117 @@
+package govydoc
+
+import (
+	"bytes"
+	"html/template"
+	"testing"
+
+	"github.com/nobl9/govy/pkg/govy"
+	"github.com/nobl9/govy/pkg/jsonpath"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nieomylnieja/govydoc/internal/testmodels"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	t.Parallel()
+
+	doc := ObjectDoc{
+		Name: "Teacher",
+		Properties: []PropertyDoc{
+			{
+				PropertyPlan:  govy.PropertyPlan{Path: jsonpath.Parse("$")},
+				ChildrenPaths: []string{"$.name", "$.oldName"},
+			},
+			{
+				PropertyPlan: govy.PropertyPlan{
+					Path: jsonpath.Parse("$.name"),
+					Rules: []govy.RulePlan{
+						{Description: "must be equal to 'John'"},
+						{Description: "property is forbidden", Conditions: []string{"when above 30"}},
+					},
+				},
+			},
+			{
+				PropertyPlan:  govy.PropertyPlan{Path: jsonpath.Parse("$.oldName")},
+				DeprecatedDoc: "Use name instead.",
+			},
+		},
+	}
+	tmpl := template.Must(template.New("doc").Funcs(FuncMap()).Parse(
+		`# {{ .Name }}
+{{ range childrenOf $ (index .Properties 0) -}}
+- {{ .Path }}{{ if isDeprecated . }} (deprecated){{ end }}
+{{ range ruleList . }}  - {{ . }}
+{{ end -}}
+{{ end -}}`,
+	))
+
+	var buf bytes.Buffer
+	err := RenderTemplate(doc, tmpl, &buf)
+
+	require.NoError(t, err)
+	assert.Equal(t, `# Teacher
+- $.name
+  - must be equal to &#39;John&#39;
+  - property is forbidden (when above 30)
+- $.oldName (deprecated)
+`, buf.String())
+}
+
+func TestRenderTemplate_NilTemplate(t *testing.T) {
+	t.Parallel()
+
+	err := RenderTemplate(ObjectDoc{}, nil, &bytes.Buffer{})
+
+	require.EqualError(t, err, "template cannot be nil")
+}
+
+func TestRenderTemplate_CollapsedScalarMaps(t *testing.T) {
+	doc, err := Generate(govy.New[testmodels.MapStruct]().WithName("MapStruct"))
+	require.NoError(t, err)
+	tmpl := template.Must(template.New("doc").Funcs(FuncMap()).Parse(
+		`| Path | Type |
+|------|------|
+{{ range collapsedProperties . }}| {{ .Path }} | {{ .TypeInfo.Name }}{{ if isScalarMap . }} (map){{ end }} |
+{{ end }}`,
+	))
+
+	var buf bytes.Buffer
+	err = RenderTemplate(doc, tmpl, &buf)
+
+	require.NoError(t, err)
+	assert.Equal(t, `| Path | Type |
+|------|------|
+| $ | MapStruct |
+| $.data | map[string]int (map) |
+`, buf.String())
+}
+
+func Test_isScalarMap(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		kind     string
+		expected bool
+	}{
+		"scalar map":          {kind: "map[string]int", expected: true},
+		"struct key":          {kind: "map[struct]string", expected: true},
+		"struct value":        {kind: "map[string]struct"},
+		"slice value":         {kind: "map[string][]int"},
+		"nested map value":    {kind: "map[string]map[string]int"},
+		"interface value":     {kind: "map[string]interface"},
+		"not a map":           {kind: "[]map[string]int"},
+		"scalar":              {kind: "string"},
+		"malformed map kind":  {kind: "map[string"},
+		"array key map value": {kind: "map[[2]int]bool", expected: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			property := PropertyDoc{PropertyPlan: govy.PropertyPlan{TypeInfo: govy.TypeInfo{Kind: test.kind}}}
+			assert.Equal(t, test.expected, isScalarMap(property))
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package govydoc
+
+import (
+	"reflect"
+
+	"github.com/nobl9/govy/pkg/govy"
+
+	"github.com/nieomylnieja/govydoc/internal/typeinfo"
+)
+
+// TypeInfoOf returns the type information of t, as set in [govy.PropertyPlan.TypeInfo] of generated properties.
+// Pointer layers are removed, built-in types have an empty package,
+// and unnamed slices of named types keep the slice notation in their name, e.g. []Student.
+// It allows building custom mappers which describe types the same way as [Generate] does.
+func TypeInfoOf(t reflect.Type) govy.TypeInfo {
+	return govy.TypeInfo(typeinfo.Get(t))
+}
@@ -0,0 +1,66 @@
+package govydoc
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/nobl9/govy/pkg/govy"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nieomylnieja/govydoc/internal/testmodels"
+)
+
+func TestTypeInfoOf(t *testing.T) {
+	t.Parallel()
+
+	const testModelsPackage = "github.com/nieomylnieja/govydoc/internal/testmodels"
+
+	tests := map[string]struct {
+		typ      reflect.Type
+		expected govy.TypeInfo
+	}{
+		"nil": {
+			expected: govy.TypeInfo{},
+		},
+		"int": {
+			typ:      reflect.TypeFor[int](),
+			expected: govy.TypeInfo{Name: "int", Kind: "int"},
+		},
+		"nested pointer to int": {
+			typ:      reflect.TypeFor[***int](),
+			expected: govy.TypeInfo{Name: "int", Kind: "int"},
+		},
+		"slice of int": {
+			typ:      reflect.TypeFor[[]int](),
+			expected: govy.TypeInfo{Name: "[]int", Kind: "[]int"},
+		},
+		"slice of struct": {
+			typ:      reflect.TypeFor[[]testmodels.Student](),
+			expected: govy.TypeInfo{Name: "[]Student", Package: testModelsPackage, Kind: "[]struct"},
+		},
+		"map of string to int": {
+			typ:      reflect.TypeFor[map[string]int](),
+			expected: govy.TypeInfo{Name: "map[string]int", Kind: "map[string]int"},
+		},
+		"custom string": {
+			typ:      reflect.TypeFor[testmodels.Currency](),
+			expected: govy.TypeInfo{Name: "Currency", Package: testModelsPackage, Kind: "string"},
+		},
+		"pointer to struct": {
+			typ:      reflect.TypeFor[*testmodels.Teacher](),
+			expected: govy.TypeInfo{Name: "Teacher", Package: testModelsPackage, Kind: "struct"},
+		},
+		"interface": {
+			typ:      reflect.TypeFor[fmt.Stringer](),
+			expected: govy.TypeInfo{Name: "Stringer", Package: "fmt", Kind: "interface"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, TypeInfoOf(test.typ))
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package govydoc
+
+import (
+	"strings"
+
+	"github.com/nobl9/govy/pkg/rules"
+)
+
+// mutuallyExclusiveDescriptionPrefix precedes the comma-separated property names
+// in the description of [rules.MutuallyExclusive] rule.
+const mutuallyExclusiveDescriptionPrefix = "properties are mutually exclusive: "
+
+// UnionGroup describes sibling properties of which at most one can be set at a time.
+type UnionGroup struct {
+	// Path is the JSON path of the properties' common parent.
+	Path string `json:"path"`
+	// Properties contains the JSON paths of the mutually exclusive properties.
+	Properties []string `json:"properties"`
+}
+
+// findUnionGroups creates a [UnionGroup] for every [rules.MutuallyExclusive] rule.
+// The rule's plan only exposes property names through its description,
+// which is why the names are parsed from it.
+// Groups with less than two members among properties are skipped, as there is no union left to document.
+func findUnionGroups(properties []PropertyDoc) []UnionGroup {
+	var groups []UnionGroup
+	for _, property := range properties {
+		for _, rule := range property.Rules {
+			if rule.ErrorCode != rules.ErrorCodeMutuallyExclusive {
+				continue
+			}
+			names, found := strings.CutPrefix(rule.Description, mutuallyExclusiveDescriptionPrefix)
+			if !found {
+				continue
+			}
+			group := UnionGroup{Path: property.Path.String()}
+			for name := range strings.SplitSeq(names, ", ") {
+				memberPath := property.Path.Name(name)
+				for _, candidate := range properties {
+					if candidate.Path.Equal(memberPath) {
+						group.Properties = append(group.Properties, memberPath.String())
+						break
+					}
+				}
+			}
+			if len(group.Properties) > 1 {
+				groups = append(groups, group)
+			}
+		}
+	}
+	return groups
+}